@@ -0,0 +1,226 @@
+// Package apollotracing adds the timing of resolvers to responses in the
+// Apollo Tracing format under extensions.tracing, and optionally in the
+// federated trace format used by Apollo Federation under extensions.ftv1.
+package apollotracing
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/shreyas44/groot"
+)
+
+type Config struct {
+	// FTV1 adds the federated trace to the responses of requests marked with
+	// WithFTV1, which gateways request with the apollo-federation-include-trace
+	// header
+	FTV1 bool
+	// OmitTracing leaves out the Apollo Tracing format, for servers which are
+	// only traced by a gateway
+	OmitTracing bool
+}
+
+type extension struct {
+	config Config
+}
+
+// NewExtension returns an extension to add to groot.SchemaConfig.Extensions
+func NewExtension(config Config) groot.FieldExtension {
+	return &extension{config}
+}
+
+type ftv1Key struct{}
+
+// WithFTV1 returns a copy of ctx which marks the request to include the
+// federated trace in its response
+func WithFTV1(ctx context.Context) context.Context {
+	return context.WithValue(ctx, ftv1Key{}, true)
+}
+
+// Middleware marks the requests with the apollo-federation-include-trace: ftv1
+// header sent by gateways with WithFTV1, to use with a handler which executes
+// requests with the context of the HTTP request
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("apollo-federation-include-trace") == "ftv1" {
+			r = r.WithContext(WithFTV1(r.Context()))
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+type requestKey struct{}
+
+// request is the timing of a request being traced
+type request struct {
+	start      time.Time
+	ftv1       bool
+	parsing    timing
+	validation timing
+
+	mu        sync.Mutex
+	resolvers []*resolver
+}
+
+type timing struct {
+	StartOffset int64 `json:"startOffset"`
+	Duration    int64 `json:"duration"`
+}
+
+type resolver struct {
+	Path        []interface{} `json:"path"`
+	ParentType  string        `json:"parentType"`
+	FieldName   string        `json:"fieldName"`
+	ReturnType  string        `json:"returnType"`
+	StartOffset int64         `json:"startOffset"`
+	Duration    int64         `json:"duration"`
+}
+
+type tracing struct {
+	Version    int       `json:"version"`
+	StartTime  time.Time `json:"startTime"`
+	EndTime    time.Time `json:"endTime"`
+	Duration   int64     `json:"duration"`
+	Parsing    timing    `json:"parsing"`
+	Validation timing    `json:"validation"`
+	Execution  struct {
+		Resolvers []*resolver `json:"resolvers"`
+	} `json:"execution"`
+}
+
+func requestFromContext(ctx context.Context) *request {
+	if ctx == nil {
+		return nil
+	}
+
+	req, _ := ctx.Value(requestKey{}).(*request)
+	return req
+}
+
+// since returns the time since the start of the request in nanoseconds
+func (req *request) since(t time.Time) int64 {
+	return int64(t.Sub(req.start))
+}
+
+// timing returns a function which records the duration of a phase of the
+// request started now
+func (req *request) timing(t *timing) func() {
+	start := time.Now()
+	t.StartOffset = req.since(start)
+	return func() {
+		t.Duration = int64(time.Since(start))
+	}
+}
+
+func (ext *extension) Init(ctx context.Context, p *graphql.Params) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	ftv1, _ := ctx.Value(ftv1Key{}).(bool)
+	return context.WithValue(ctx, requestKey{}, &request{
+		start: time.Now(),
+		ftv1:  ext.config.FTV1 && ftv1,
+	})
+}
+
+func (ext *extension) Name() string {
+	return "tracing"
+}
+
+func (ext *extension) ParseDidStart(ctx context.Context) (context.Context, graphql.ParseFinishFunc) {
+	req := requestFromContext(ctx)
+	if req == nil {
+		return ctx, func(err error) {}
+	}
+
+	finish := req.timing(&req.parsing)
+	return ctx, func(err error) { finish() }
+}
+
+func (ext *extension) ValidationDidStart(ctx context.Context) (context.Context, graphql.ValidationFinishFunc) {
+	req := requestFromContext(ctx)
+	if req == nil {
+		return ctx, func(errs []gqlerrors.FormattedError) {}
+	}
+
+	finish := req.timing(&req.validation)
+	return ctx, func(errs []gqlerrors.FormattedError) { finish() }
+}
+
+func (ext *extension) ExecutionDidStart(ctx context.Context) (context.Context, graphql.ExecutionFinishFunc) {
+	req := requestFromContext(ctx)
+	if req == nil {
+		return ctx, func(result *graphql.Result) {}
+	}
+
+	return ctx, func(result *graphql.Result) {
+		end := time.Now()
+
+		req.mu.Lock()
+		defer req.mu.Unlock()
+
+		if result.Extensions == nil {
+			result.Extensions = map[string]interface{}{}
+		}
+
+		if !ext.config.OmitTracing {
+			trace := &tracing{
+				Version:    1,
+				StartTime:  req.start.UTC(),
+				EndTime:    end.UTC(),
+				Duration:   req.since(end),
+				Parsing:    req.parsing,
+				Validation: req.validation,
+			}
+
+			trace.Execution.Resolvers = append([]*resolver{}, req.resolvers...)
+			result.Extensions["tracing"] = trace
+		}
+
+		if req.ftv1 {
+			result.Extensions["ftv1"] = encodeFTV1(req, end, result.Errors)
+		}
+	}
+}
+
+func (ext *extension) ResolveFieldDidStart(ctx context.Context, info *graphql.ResolveInfo) (context.Context, graphql.ResolveFieldFinishFunc) {
+	return ctx, func(v interface{}, err error) {}
+}
+
+func (ext *extension) HasResult() bool {
+	return false
+}
+
+func (ext *extension) GetResult(ctx context.Context) interface{} {
+	return nil
+}
+
+func (ext *extension) FieldDidStart(ctx context.Context, field groot.FieldInfo) (context.Context, groot.FieldFinishFunc) {
+	req := requestFromContext(ctx)
+	if req == nil {
+		return ctx, func(interface{}, error) {}
+	}
+
+	info := field.Info
+	start := time.Now()
+	return ctx, func(result interface{}, err error) {
+		resolver := &resolver{
+			Path:        info.Path.AsArray(),
+			ParentType:  info.ParentType.Name(),
+			FieldName:   info.FieldName,
+			ReturnType:  info.ReturnType.String(),
+			StartOffset: req.since(start),
+			Duration:    int64(time.Since(start)),
+		}
+
+		req.mu.Lock()
+		req.resolvers = append(req.resolvers, resolver)
+		req.mu.Unlock()
+	}
+}
@@ -0,0 +1,281 @@
+package apollotracing_test
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/shreyas44/groot"
+	"github.com/shreyas44/groot/apollotracing"
+)
+
+type Query struct {
+	Hello string   `json:"hello"`
+	Items []string `json:"items"`
+}
+
+func (query Query) ResolveHello() (string, error) {
+	return "world", nil
+}
+
+func (query Query) ResolveItems() ([]string, []error) {
+	return []string{"a", "b"}, []error{groot.ErrorAt(groot.NewError("FAILED", "failed"), 1)}
+}
+
+func newExecutor(t *testing.T, config apollotracing.Config) *groot.Executor {
+	executor, err := groot.NewExecutor(groot.SchemaConfig{
+		Query:      groot.MustParseObject(Query{}),
+		Extensions: []graphql.Extension{apollotracing.NewExtension(config)},
+	}, groot.ExecutorConfig{})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return executor
+}
+
+// protoMessage is a decoded protobuf message, with the varints and the bytes
+// of length delimited fields by their field number
+type protoMessage struct {
+	varints map[int][]uint64
+	bytes   map[int][][]byte
+}
+
+func decodeProto(t *testing.T, buf []byte) protoMessage {
+	t.Helper()
+	msg := protoMessage{map[int][]uint64{}, map[int][][]byte{}}
+
+	readVarint := func() uint64 {
+		var v uint64
+		for shift := uint(0); ; shift += 7 {
+			if len(buf) == 0 {
+				t.Fatal("truncated varint")
+			}
+
+			b := buf[0]
+			buf = buf[1:]
+			v |= uint64(b&0x7f) << shift
+			if b < 0x80 {
+				return v
+			}
+		}
+	}
+
+	for len(buf) != 0 {
+		tag := readVarint()
+		field := int(tag >> 3)
+		switch tag & 7 {
+		case 0:
+			msg.varints[field] = append(msg.varints[field], readVarint())
+		case 2:
+			length := int(readVarint())
+			if length > len(buf) {
+				t.Fatal("truncated message")
+			}
+
+			msg.bytes[field] = append(msg.bytes[field], buf[:length])
+			buf = buf[length:]
+		default:
+			t.Fatalf("unexpected wire type %d", tag&7)
+		}
+	}
+
+	return msg
+}
+
+func (msg protoMessage) string(field int) string {
+	if len(msg.bytes[field]) == 0 {
+		return ""
+	}
+
+	return string(msg.bytes[field][0])
+}
+
+func (msg protoMessage) varint(field int) uint64 {
+	if len(msg.varints[field]) == 0 {
+		return 0
+	}
+
+	return msg.varints[field][0]
+}
+
+// child returns the child node of a Trace.Node with a response name
+func child(t *testing.T, node protoMessage, responseName string) protoMessage {
+	t.Helper()
+	for _, childBytes := range node.bytes[12] {
+		if child := decodeProto(t, childBytes); child.string(1) == responseName {
+			return child
+		}
+	}
+
+	t.Fatalf("no child named %s", responseName)
+	return protoMessage{}
+}
+
+func TestFTV1(t *testing.T) {
+	executor := newExecutor(t, apollotracing.Config{FTV1: true, OmitTracing: true})
+
+	// the errors of fields are added to the result by the finish func of
+	// another extension, which graphql-go calls in a random order
+	for i := 0; i < 50; i++ {
+		result := executor.Execute(groot.Request{
+			Query:   "{ greeting: hello items }",
+			Context: apollotracing.WithFTV1(context.Background()),
+		})
+
+		if _, ok := result.Extensions["tracing"]; ok {
+			t.Fatal("expected extensions.tracing to be omitted")
+		}
+
+		encoded, _ := result.Extensions["ftv1"].(string)
+		traceBytes, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		trace := decodeProto(t, traceBytes)
+		endTime := decodeProto(t, trace.bytes[3][0])
+		startTime := decodeProto(t, trace.bytes[4][0])
+		if startTime.varint(1) == 0 || endTime.varint(1) < startTime.varint(1) {
+			t.Fatal("expected the start and end time of the trace")
+		}
+
+		if trace.varint(11) == 0 {
+			t.Fatal("expected the duration of the trace")
+		}
+
+		root := decodeProto(t, trace.bytes[14][0])
+
+		greeting := child(t, root, "greeting")
+		if greeting.string(14) != "hello" || greeting.string(13) != "Query" || greeting.string(3) != "String!" {
+			t.Fatalf("unexpected node of greeting %v", greeting)
+		}
+
+		if greeting.varint(9) < greeting.varint(8) || greeting.varint(9) == 0 {
+			t.Fatal("expected the start and end time of greeting")
+		}
+
+		items := child(t, root, "items")
+		if items.string(14) != "" {
+			t.Fatal("expected no original field name for fields without an alias")
+		}
+
+		if len(items.bytes[12]) != 1 {
+			t.Fatalf("expected a node for the item with the error, got %d nodes", len(items.bytes[12]))
+		}
+
+		item := decodeProto(t, items.bytes[12][0])
+		if len(item.varints[2]) != 1 || item.varint(2) != 1 {
+			t.Fatalf("expected the node of index 1, got %v", item.varints)
+		}
+
+		if len(item.bytes[11]) != 1 {
+			t.Fatalf("expected the error of the item, got %d errors", len(item.bytes[11]))
+		}
+
+		itemError := decodeProto(t, item.bytes[11][0])
+		if itemError.string(1) != "failed" || len(itemError.bytes[2]) != 1 || itemError.string(4) == "" {
+			t.Fatalf("unexpected error %v", itemError)
+		}
+	}
+}
+
+func TestTracing(t *testing.T) {
+	executor := newExecutor(t, apollotracing.Config{})
+	result := executor.Execute(groot.Request{Query: "{ hello }"})
+
+	if _, ok := result.Extensions["ftv1"]; ok {
+		t.Fatal("expected no federated trace for requests without WithFTV1")
+	}
+
+	tracingJSON, err := json.Marshal(result.Extensions["tracing"])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var tracing struct {
+		Version    int     `json:"version"`
+		StartTime  string  `json:"startTime"`
+		EndTime    string  `json:"endTime"`
+		Duration   int64   `json:"duration"`
+		Parsing    *timing `json:"parsing"`
+		Validation *timing `json:"validation"`
+		Execution  struct {
+			Resolvers []struct {
+				Path        []interface{} `json:"path"`
+				ParentType  string        `json:"parentType"`
+				FieldName   string        `json:"fieldName"`
+				ReturnType  string        `json:"returnType"`
+				StartOffset int64         `json:"startOffset"`
+				Duration    int64         `json:"duration"`
+			} `json:"resolvers"`
+		} `json:"execution"`
+	}
+
+	if err := json.Unmarshal(tracingJSON, &tracing); err != nil {
+		t.Fatal(err)
+	}
+
+	if tracing.Version != 1 || tracing.StartTime == "" || tracing.EndTime == "" || tracing.Duration <= 0 {
+		t.Fatalf("unexpected tracing %s", tracingJSON)
+	}
+
+	if tracing.Parsing == nil || tracing.Validation == nil {
+		t.Fatalf("expected the timing of parsing and validation, got %s", tracingJSON)
+	}
+
+	resolvers := tracing.Execution.Resolvers
+	if len(resolvers) != 1 {
+		t.Fatalf("expected 1 resolver, got %s", tracingJSON)
+	}
+
+	resolver := resolvers[0]
+	if len(resolver.Path) != 1 || resolver.Path[0] != "hello" || resolver.ParentType != "Query" ||
+		resolver.FieldName != "hello" || resolver.ReturnType != "String!" || resolver.Duration <= 0 {
+		t.Fatalf("unexpected resolver %s", tracingJSON)
+	}
+}
+
+type timing struct {
+	StartOffset int64 `json:"startOffset"`
+	Duration    int64 `json:"duration"`
+}
+
+func TestMiddleware(t *testing.T) {
+	executor := newExecutor(t, apollotracing.Config{FTV1: true})
+	handler := apollotracing.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		result := executor.Execute(groot.Request{Query: "{ hello }", Context: r.Context()})
+		json.NewEncoder(w).Encode(result)
+	}))
+
+	hasFTV1 := func(header string) bool {
+		r := httptest.NewRequest(http.MethodPost, "/graphql", nil)
+		if header != "" {
+			r.Header.Set("apollo-federation-include-trace", header)
+		}
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+
+		var result struct {
+			Extensions map[string]interface{} `json:"extensions"`
+		}
+
+		json.NewDecoder(w.Body).Decode(&result)
+		_, ok := result.Extensions["ftv1"]
+		return ok
+	}
+
+	if !hasFTV1("ftv1") {
+		t.Fatal("expected a federated trace for requests with the header")
+	}
+
+	if hasFTV1("") {
+		t.Fatal("expected no federated trace for requests without the header")
+	}
+}
@@ -0,0 +1,182 @@
+package apollotracing
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
+	"github.com/graphql-go/graphql/gqlerrors"
+)
+
+// node is a node of the tree of fields of a federated trace, which is either
+// a field or an item of a list
+type node struct {
+	responseName      string
+	index             int
+	isIndex           bool
+	originalFieldName string
+	returnType        string
+	parentType        string
+	startTime         uint64
+	endTime           uint64
+	errors            []gqlerrors.FormattedError
+	children          []*node
+	childrenByKey     map[interface{}]*node
+}
+
+// child returns the child of a node with the given response name or index,
+// creating it if it doesn't exist
+func (n *node) child(key interface{}) *node {
+	if child, ok := n.childrenByKey[key]; ok {
+		return child
+	}
+
+	child := &node{childrenByKey: map[interface{}]*node{}}
+	switch key := key.(type) {
+	case int:
+		child.index = key
+		child.isIndex = true
+	default:
+		child.responseName, _ = key.(string)
+	}
+
+	n.children = append(n.children, child)
+	n.childrenByKey[key] = child
+	return child
+}
+
+func (n *node) descendant(path []interface{}) *node {
+	for _, key := range path {
+		n = n.child(key)
+	}
+
+	return n
+}
+
+// encodeFTV1 encodes the trace of a request as the Trace message of Apollo's
+// reports.proto, encoded in base64
+func encodeFTV1(req *request, end time.Time, errs []gqlerrors.FormattedError) string {
+	root := &node{childrenByKey: map[interface{}]*node{}}
+	for _, resolver := range req.resolvers {
+		field := root.descendant(resolver.Path)
+		field.returnType = resolver.ReturnType
+		field.parentType = resolver.ParentType
+		field.startTime = uint64(resolver.StartOffset)
+		field.endTime = uint64(resolver.StartOffset + resolver.Duration)
+		if field.responseName != resolver.FieldName {
+			field.originalFieldName = resolver.FieldName
+		}
+	}
+
+	for _, err := range errs {
+		n := root.descendant(err.Path)
+		n.errors = append(n.errors, err)
+	}
+
+	var trace protoBuffer
+	trace.message(3, encodeTimestamp(end))
+	trace.message(4, encodeTimestamp(req.start))
+	trace.uint64(11, uint64(req.since(end)))
+	trace.message(14, encodeNode(root))
+
+	return base64.StdEncoding.EncodeToString(trace)
+}
+
+func encodeTimestamp(t time.Time) []byte {
+	var timestamp protoBuffer
+	timestamp.uint64(1, uint64(t.Unix()))
+	timestamp.uint64(2, uint64(t.Nanosecond()))
+	return timestamp
+}
+
+func encodeNode(n *node) []byte {
+	var buf protoBuffer
+	if n.isIndex {
+		// the index is part of a oneof, so it's encoded even if it's zero
+		buf.tag(2, wireVarint)
+		buf.varint(uint64(n.index))
+	} else {
+		buf.string(1, n.responseName)
+	}
+
+	buf.string(3, n.returnType)
+	buf.uint64(8, n.startTime)
+	buf.uint64(9, n.endTime)
+	for _, err := range n.errors {
+		buf.message(11, encodeError(err))
+	}
+
+	for _, child := range n.children {
+		buf.message(12, encodeNode(child))
+	}
+
+	buf.string(13, n.parentType)
+	buf.string(14, n.originalFieldName)
+	return buf
+}
+
+func encodeError(err gqlerrors.FormattedError) []byte {
+	var buf protoBuffer
+	buf.string(1, err.Message)
+	for _, location := range err.Locations {
+		var loc protoBuffer
+		loc.uint64(1, uint64(location.Line))
+		loc.uint64(2, uint64(location.Column))
+		buf.message(2, loc)
+	}
+
+	if errJSON, jsonErr := json.Marshal(err); jsonErr == nil {
+		buf.string(4, string(errJSON))
+	}
+
+	return buf
+}
+
+const (
+	wireVarint          = 0
+	wireLengthDelimited = 2
+)
+
+// protoBuffer encodes protocol buffer messages, which saves depending on a
+// protobuf library for a single message
+type protoBuffer []byte
+
+func (buf *protoBuffer) varint(v uint64) {
+	for v >= 0x80 {
+		*buf = append(*buf, byte(v)|0x80)
+		v >>= 7
+	}
+
+	*buf = append(*buf, byte(v))
+}
+
+func (buf *protoBuffer) tag(field int, wireType int) {
+	buf.varint(uint64(field<<3 | wireType))
+}
+
+// uint64 encodes a varint field, which is left out if it's zero
+func (buf *protoBuffer) uint64(field int, v uint64) {
+	if v == 0 {
+		return
+	}
+
+	buf.tag(field, wireVarint)
+	buf.varint(v)
+}
+
+// string encodes a string field, which is left out if it's empty
+func (buf *protoBuffer) string(field int, s string) {
+	if s == "" {
+		return
+	}
+
+	buf.tag(field, wireLengthDelimited)
+	buf.varint(uint64(len(s)))
+	*buf = append(*buf, s...)
+}
+
+func (buf *protoBuffer) message(field int, msg []byte) {
+	buf.tag(field, wireLengthDelimited)
+	buf.varint(uint64(len(msg)))
+	*buf = append(*buf, msg...)
+}
@@ -1,19 +1,37 @@
 package groot
 
 import (
+	"fmt"
+
 	"github.com/graphql-go/graphql"
 	"github.com/shreyas44/groot/parser"
 )
 
 func NewArgument(parserArg *parser.Argument, builder *SchemaBuilder) *graphql.ArgumentConfig {
 	graphqlType := getOrCreateType(parserArg.Type(), builder)
+	// graphql-go requires non null arguments to be provided even if they
+	// have a default value, so arguments with a default value are optional
+	if parserArg.DefaultValue() != "" || builder.omitEmptyNullable && parserArg.OmitEmpty() {
+		graphqlType = GetNullable(graphqlType).(graphql.Type)
+	}
+
 	argument := &graphql.ArgumentConfig{
 		Type:        graphqlType,
 		Description: parserArg.Description(),
 	}
 
 	if parserArg.DefaultValue() != "" {
-		argument.DefaultValue = parserArg.DefaultValue()
+		defaultValue, err := parseDefaultValue(parserArg.DefaultValue(), graphqlType)
+		if err != nil {
+			builder.addError(fmt.Errorf(
+				"invalid default value for field %s on struct %s: %s",
+				parserArg.StructField().Name,
+				parserArg.Input().ReflectType().Name(),
+				err,
+			))
+		}
+
+		argument.DefaultValue = defaultValue
 	}
 
 	return argument
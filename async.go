@@ -0,0 +1,117 @@
+package groot
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/graphql-go/graphql"
+)
+
+// DefaultMaxConcurrency is the number of resolvers run in goroutines at the
+// same time for a request if SchemaConfig.MaxConcurrency isn't set
+const DefaultMaxConcurrency = 16
+
+type workerPoolKey struct{}
+
+// workerPool bounds the number of resolvers of a request run in goroutines
+type workerPool struct {
+	slots chan struct{}
+}
+
+func newWorkerPool(size int) *workerPool {
+	if size <= 0 {
+		size = DefaultMaxConcurrency
+	}
+
+	return &workerPool{slots: make(chan struct{}, size)}
+}
+
+// run runs fn in a goroutine once a slot is free, blocking until then so no
+// more than the size of the pool goroutines are started. It returns the error
+// of ctx without running fn if ctx is done first.
+func (pool *workerPool) run(ctx context.Context, fn func()) error {
+	select {
+	case pool.slots <- struct{}{}:
+	case <-contextDone(ctx):
+		return ctx.Err()
+	}
+
+	go func() {
+		defer func() { <-pool.slots }()
+
+		fn()
+	}()
+
+	return nil
+}
+
+func workerPoolFromContext(ctx context.Context) *workerPool {
+	if ctx == nil {
+		return nil
+	}
+
+	pool, _ := ctx.Value(workerPoolKey{}).(*workerPool)
+	return pool
+}
+
+// newAsyncFieldResolver runs a resolver in a goroutine and returns a thunk
+// waiting for its result, so sibling fields are resolved in parallel
+func newAsyncFieldResolver(resolve fieldResolver) fieldResolver {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		pool := workerPoolFromContext(p.Context)
+
+		// the request wasn't executed with the extension of the schema
+		if pool == nil {
+			return resolve(p)
+		}
+
+		var (
+			value interface{}
+			err   error
+			done  = make(chan struct{})
+		)
+
+		runErr := pool.run(p.Context, func() {
+			defer close(done)
+			defer func() {
+				// graphql-go can't recover panics from other goroutines
+				if r := recover(); r != nil {
+					err = fmt.Errorf("%v", r)
+				}
+			}()
+
+			value, err = resolve(p)
+
+			// the result is abandoned if the resolver returns after the
+			// deadline of the field, even if the thunk is called later
+			if p.Context != nil && p.Context.Err() != nil {
+				value, err = nil, p.Context.Err()
+			}
+		})
+
+		if runErr != nil {
+			return nil, runErr
+		}
+
+		// the context has a deadline if the field has a timeout, after which
+		// the result of the resolver is abandoned
+		return func() (interface{}, error) {
+			select {
+			case <-done:
+				return value, err
+			case <-contextDone(p.Context):
+				return nil, p.Context.Err()
+			}
+		}, nil
+	}
+}
+
+// contextDone returns the done channel of ctx, or nil if ctx is nil, which
+// blocks forever when received from
+func contextDone(ctx context.Context) <-chan struct{} {
+	if ctx == nil {
+		return nil
+	}
+
+	return ctx.Done()
+}
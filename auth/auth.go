@@ -0,0 +1,137 @@
+// Package auth authorizes access to fields with roles required by the auth
+// struct tag, and with the Authorize method of object types.
+package auth
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+
+	"github.com/shreyas44/groot"
+	"github.com/shreyas44/groot/parser"
+)
+
+// CodeForbidden is the code of errors of fields which cannot be accessed
+const CodeForbidden = "FORBIDDEN"
+
+// Principal is the user or service making a request
+type Principal interface {
+	HasRole(role string) bool
+}
+
+// Authorizer is implemented by object types which restrict access to them.
+// Authorize is called on every object of the type a field resolves to,
+// including objects in lists and objects returned for interfaces and unions,
+// before any of its fields are resolved.
+type Authorizer interface {
+	Authorize(ctx context.Context) error
+}
+
+// Policy reports whether a request can access a field which requires any of
+// the given roles
+type Policy func(ctx context.Context, roles []string) bool
+
+type Config struct {
+	// Policy decides whether a request has the roles required by fields,
+	// HasAnyRole by default
+	Policy Policy
+}
+
+type principalKey struct{}
+
+// WithPrincipal returns a copy of ctx with the principal making the request
+func WithPrincipal(ctx context.Context, principal Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// PrincipalFromContext returns the principal added to ctx with WithPrincipal
+func PrincipalFromContext(ctx context.Context) (Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(Principal)
+	return principal, ok
+}
+
+// HasAnyRole is the default policy, which allows requests by a principal
+// with any of the roles
+func HasAnyRole(ctx context.Context, roles []string) bool {
+	principal, ok := PrincipalFromContext(ctx)
+	if !ok {
+		return false
+	}
+
+	for _, role := range roles {
+		if principal.HasRole(role) {
+			return true
+		}
+	}
+
+	return false
+}
+
+type authorizer struct {
+	config Config
+}
+
+// New returns an authorizer to set as groot.SchemaConfig.Authorizer
+func New(config Config) groot.Authorizer {
+	if config.Policy == nil {
+		config.Policy = HasAnyRole
+	}
+
+	return &authorizer{config: config}
+}
+
+func (a *authorizer) FieldRule(field *parser.Field) groot.FieldRule {
+	roles := getRoles(field)
+	if len(roles) == 0 {
+		return nil
+	}
+
+	policy := a.config.Policy
+	return func(ctx context.Context) error {
+		if !policy(ctx, roles) {
+			return groot.NewError(CodeForbidden, "not authorized to access this field")
+		}
+
+		return nil
+	}
+}
+
+func (a *authorizer) AuthorizeObject(ctx context.Context, object interface{}) error {
+	authz, ok := object.(Authorizer)
+
+	// the method may be defined on the pointer to the type
+	if value := reflect.ValueOf(object); !ok && value.Kind() != reflect.Ptr {
+		ptr := reflect.New(value.Type())
+		ptr.Elem().Set(value)
+		authz, ok = ptr.Interface().(Authorizer)
+	}
+
+	if !ok {
+		return nil
+	}
+
+	err := authz.Authorize(ctx)
+	if err == nil {
+		return nil
+	}
+
+	var grootErr *groot.Error
+	if errors.As(err, &grootErr) {
+		return err
+	}
+
+	return groot.WrapError(err, CodeForbidden, err.Error())
+}
+
+// getRoles returns the roles listed in the auth tag of a field
+func getRoles(field *parser.Field) []string {
+	roles := []string{}
+	for _, role := range strings.Split(field.StructField().Tag.Get("auth"), ",") {
+		if role = strings.TrimSpace(role); role != "" {
+			roles = append(roles, role)
+		}
+	}
+
+	return roles
+}
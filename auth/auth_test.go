@@ -0,0 +1,257 @@
+package auth_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/shreyas44/groot"
+	"github.com/shreyas44/groot/auth"
+	"github.com/shreyas44/groot/parser"
+)
+
+type user struct {
+	id    string
+	roles []string
+}
+
+func (u *user) HasRole(role string) bool {
+	for _, r := range u.roles {
+		if r == role {
+			return true
+		}
+	}
+
+	return false
+}
+
+type Node interface {
+	ImplementsNode() NodeDefinition
+}
+
+type NodeDefinition struct {
+	groot.InterfaceType
+	ID groot.ID `json:"id"`
+}
+
+func (definition NodeDefinition) ImplementsNode() NodeDefinition {
+	return definition
+}
+
+type Document struct {
+	NodeDefinition
+	OwnerID string  `json:"-"`
+	Secret  *string `json:"secret" auth:"admin"`
+}
+
+func (document Document) Authorize(ctx context.Context) error {
+	principal, ok := auth.PrincipalFromContext(ctx)
+	if !ok || principal.(*user).id != document.OwnerID {
+		return errors.New("not your document")
+	}
+
+	return nil
+}
+
+type Folder struct {
+	Name string `json:"name"`
+}
+
+func (folder Folder) Authorize(ctx context.Context) error {
+	return fmt.Errorf("folder %s: %w", folder.Name, groot.NewError("ARCHIVED", "folder is archived"))
+}
+
+type SearchResult struct {
+	groot.UnionType
+	Document
+	Folder
+}
+
+type Query struct {
+	Document  *Document     `json:"document"`
+	Documents *[]Document   `json:"documents"`
+	Node      Node          `json:"node"`
+	Search    *SearchResult `json:"search"`
+	Folder    *Folder       `json:"folder"`
+}
+
+var secret = "secret"
+
+func ownedBy(owner string) Document {
+	return Document{NodeDefinition: NodeDefinition{ID: "1"}, OwnerID: owner, Secret: &secret}
+}
+
+func (q Query) ResolveDocument() (*Document, error) {
+	document := ownedBy("alice")
+	return &document, nil
+}
+
+func (q Query) ResolveDocuments() ([]Document, error) {
+	return []Document{ownedBy("alice"), ownedBy("bob")}, nil
+}
+
+func (q Query) ResolveNode() (Node, error) {
+	return ownedBy("alice"), nil
+}
+
+func (q Query) ResolveSearch() (*SearchResult, error) {
+	return &SearchResult{Document: ownedBy("alice")}, nil
+}
+
+func (q Query) ResolveFolder() (*Folder, error) {
+	return &Folder{Name: "2021"}, nil
+}
+
+func newSchema(t *testing.T) graphql.Schema {
+	t.Helper()
+	return newSchemaWithConfig(t, groot.SchemaConfig{})
+}
+
+func newSchemaWithConfig(t *testing.T, config groot.SchemaConfig) graphql.Schema {
+	t.Helper()
+
+	config.Query = groot.MustParseObject(Query{})
+	config.Types = []parser.Type{groot.MustParseObject(Document{})}
+	config.Authorizer = auth.New(auth.Config{})
+	schema, err := groot.NewSchema(config)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return schema
+}
+
+func TestObjectAuthorization(t *testing.T) {
+	schema := newSchema(t)
+	queries := map[string]string{
+		"object":    `{ document { id } }`,
+		"list":      `{ documents { id } }`,
+		"interface": `{ node { id } }`,
+		"union":     `{ search { ... on Document { id } } }`,
+	}
+
+	for name, query := range queries {
+		t.Run(name, func(t *testing.T) {
+			ctx := auth.WithPrincipal(context.Background(), &user{id: "bob"})
+			result := graphql.Do(graphql.Params{Schema: schema, RequestString: query, Context: ctx})
+			if len(result.Errors) != 1 || result.Errors[0].Extensions["code"] != auth.CodeForbidden {
+				t.Fatalf("expected a forbidden error, got %v", result.Errors)
+			}
+		})
+	}
+}
+
+func TestObjectAuthorizationOfOwnObject(t *testing.T) {
+	schema := newSchema(t)
+	ctx := auth.WithPrincipal(context.Background(), &user{id: "alice"})
+	for _, query := range []string{`{ document { id } }`, `{ node { id } }`, `{ search { ... on Document { id } } }`} {
+		result := graphql.Do(graphql.Params{Schema: schema, RequestString: query, Context: ctx})
+		if len(result.Errors) != 0 {
+			t.Fatalf("%s: unexpected errors %v", query, result.Errors)
+		}
+	}
+}
+
+func TestFieldRoles(t *testing.T) {
+	schema := newSchema(t)
+	tests := []struct {
+		roles  []string
+		errors int
+	}{
+		{nil, 1},
+		{[]string{"admin"}, 0},
+	}
+
+	for _, test := range tests {
+		ctx := auth.WithPrincipal(context.Background(), &user{id: "alice", roles: test.roles})
+		result := graphql.Do(graphql.Params{Schema: schema, RequestString: `{ document { secret } }`, Context: ctx})
+		if len(result.Errors) != test.errors {
+			t.Fatalf("roles %v: expected %d errors, got %v", test.roles, test.errors, result.Errors)
+		}
+	}
+}
+
+func TestObjectAuthorizationKeepsWrappedErrors(t *testing.T) {
+	schema := newSchema(t)
+	result := graphql.Do(graphql.Params{Schema: schema, RequestString: `{ folder { name } }`})
+	if len(result.Errors) != 1 || result.Errors[0].Extensions["code"] != "ARCHIVED" {
+		t.Fatalf("expected an archived error, got %v", result.Errors)
+	}
+}
+
+func TestHideUnauthorizedFields(t *testing.T) {
+	query := `{
+		__type(name: "Document") { fields { name } }
+		__schema { types { name fields { name type { fields { name } } } } }
+	}`
+
+	tests := []struct {
+		name   string
+		hide   bool
+		roles  []string
+		secret bool
+	}{
+		{"not hidden", false, nil, true},
+		{"hidden", true, nil, false},
+		{"allowed", true, []string{"admin"}, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			schema := newSchemaWithConfig(t, groot.SchemaConfig{HideUnauthorizedFields: test.hide})
+			ctx := auth.WithPrincipal(context.Background(), &user{id: "alice", roles: test.roles})
+			result := graphql.Do(graphql.Params{Schema: schema, RequestString: query, Context: ctx})
+			if len(result.Errors) != 0 {
+				t.Fatal(result.Errors)
+			}
+
+			data := result.Data.(map[string]interface{})
+			expected := []string{"id"}
+			if test.secret {
+				expected = append(expected, "secret")
+			}
+
+			if fields := fieldNames(data["__type"]); !reflect.DeepEqual(fields, expected) {
+				t.Fatalf("expected %v, got %v", expected, fields)
+			}
+
+			types := data["__schema"].(map[string]interface{})["types"].([]interface{})
+			for _, typ := range types {
+				typ := typ.(map[string]interface{})
+				if typ["name"] == "Document" {
+					if fields := fieldNames(typ); !reflect.DeepEqual(fields, expected) {
+						t.Fatalf("expected %v, got %v", expected, fields)
+					}
+				}
+
+				// the fields of the types of query fields are nested lists
+				if typ["name"] == "Query" {
+					for _, field := range typ["fields"].([]interface{}) {
+						field := field.(map[string]interface{})
+						if field["name"] != "document" {
+							continue
+						}
+
+						fieldType := field["type"].(map[string]interface{})
+						if fields := fieldNames(fieldType); !reflect.DeepEqual(fields, expected) {
+							t.Fatalf("expected %v, got %v", expected, fields)
+						}
+					}
+				}
+			}
+		})
+	}
+}
+
+func fieldNames(typ interface{}) []string {
+	names := []string{}
+	for _, field := range typ.(map[string]interface{})["fields"].([]interface{}) {
+		names = append(names, field.(map[string]interface{})["name"].(string))
+	}
+
+	return names
+}
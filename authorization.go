@@ -0,0 +1,130 @@
+package groot
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/graphql-go/graphql"
+	"github.com/shreyas44/groot/parser"
+)
+
+// FieldRule authorizes access to a field in the context of a request, and
+// returns an error if the field cannot be accessed
+type FieldRule func(ctx context.Context) error
+
+// Authorizer authorizes access to fields before they are resolved, and to the
+// objects they resolve to. The auth package implements it with roles set with
+// the auth tag and the Authorize method of objects.
+type Authorizer interface {
+	// FieldRule returns the rule of a field, or nil if the field can always
+	// be accessed
+	FieldRule(field *parser.Field) FieldRule
+	// AuthorizeObject authorizes access to an object a field resolves to,
+	// which is called with every object in lists, and with the concrete type
+	// of interfaces and unions
+	AuthorizeObject(ctx context.Context, object interface{}) error
+}
+
+type fieldKey struct {
+	parentType graphql.Type
+	name       string
+}
+
+// authorizeField checks the rule of a field before it's resolved, and the
+// objects it resolves to. Fields which cannot be accessed resolve to null,
+// or make their parent resolve to null if they're non null.
+func (builder *SchemaBuilder) authorizeField(parentType graphql.Type, parserField *parser.Field, field *graphql.Field) {
+	if builder.authorizer == nil {
+		return
+	}
+
+	switch namedType(parserField.Type()).(type) {
+	case *parser.Object, *parser.Interface, *parser.Union:
+		field.Resolve = newValueFieldResolver(field.Resolve, func(p graphql.ResolveParams, value interface{}) (interface{}, error) {
+			if err := builder.authorizeObjects(requestContext(p), reflect.ValueOf(value)); err != nil {
+				return nil, presentError(p, err)
+			}
+
+			return value, nil
+		})
+	}
+
+	rule := builder.authorizer.FieldRule(parserField)
+	if rule == nil {
+		return
+	}
+
+	authorize := func(resolve fieldResolver) fieldResolver {
+		return func(p graphql.ResolveParams) (interface{}, error) {
+			if err := rule(requestContext(p)); err != nil {
+				return nil, presentError(p, err)
+			}
+
+			return resolve(p)
+		}
+	}
+
+	builder.fieldRules[fieldKey{parentType, field.Name}] = rule
+	field.Resolve = authorize(field.Resolve)
+	if field.Subscribe != nil {
+		field.Subscribe = authorize(field.Subscribe)
+	}
+}
+
+// authorizeObjects authorizes the objects in the value of a field, which is
+// an object or a list of objects
+func (builder *SchemaBuilder) authorizeObjects(ctx context.Context, value reflect.Value) error {
+	switch value.Kind() {
+	case reflect.Interface, reflect.Ptr:
+		if value.IsNil() {
+			return nil
+		}
+
+		if value.Kind() == reflect.Interface || value.Elem().Kind() != reflect.Struct {
+			return builder.authorizeObjects(ctx, value.Elem())
+		}
+
+		return builder.authorizer.AuthorizeObject(ctx, value.Interface())
+
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			if err := builder.authorizeObjects(ctx, value.Index(i)); err != nil {
+				return err
+			}
+		}
+
+	case reflect.Struct:
+		return builder.authorizer.AuthorizeObject(ctx, value.Interface())
+	}
+
+	return nil
+}
+
+// getFieldDefinitionRules returns the rules of fields by their definitions,
+// which are what introspection queries resolve to
+func (builder *SchemaBuilder) getFieldDefinitionRules() map[*graphql.FieldDefinition]FieldRule {
+	rules := map[*graphql.FieldDefinition]FieldRule{}
+	for key, rule := range builder.fieldRules {
+		var fields graphql.FieldDefinitionMap
+		switch parentType := key.parentType.(type) {
+		case *graphql.Object:
+			fields = parentType.Fields()
+		case *graphql.Interface:
+			fields = parentType.Fields()
+		}
+
+		if field, ok := fields[key.name]; ok {
+			rules[field] = rule
+		}
+	}
+
+	return rules
+}
+
+func requestContext(p graphql.ResolveParams) context.Context {
+	if p.Context == nil {
+		return context.Background()
+	}
+
+	return p.Context
+}
@@ -0,0 +1,255 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/doc"
+	"go/format"
+	goparser "go/parser"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+type describedType struct {
+	Name        string
+	Description string
+	Fields      []describedValue
+	Values      []describedValue
+}
+
+type describedValue struct {
+	Name        string
+	Description string
+}
+
+var describeTemplate = template.Must(template.New("describe").Parse(`// Code generated by groot describe. DO NOT EDIT.
+
+package {{ .Package }}
+{{ if .Types }}
+import (
+	"reflect"
+
+	grootparser "github.com/shreyas44/groot/parser"
+)
+
+func init() {
+{{- range .Types }}
+	grootparser.RegisterDescriptions(reflect.TypeOf((*{{ .Name }})(nil)).Elem(), grootparser.TypeDescriptions{
+		{{- if .Description }}
+		Description: {{ printf "%q" .Description }},
+		{{- end }}
+		{{- if .Fields }}
+		Fields: map[string]string{
+			{{- range .Fields }}
+			{{ printf "%q" .Name }}: {{ printf "%q" .Description }},
+			{{- end }}
+		},
+		{{- end }}
+		{{- if .Values }}
+		Values: []grootparser.ValueDescription{
+			{{- range .Values }}
+			{Value: {{ .Name }}, Description: {{ printf "%q" .Description }}},
+			{{- end }}
+		},
+		{{- end }}
+	})
+{{- end }}
+}
+{{ end -}}
+`))
+
+func runDescribe(args []string) error {
+	flags := flag.NewFlagSet("describe", flag.ExitOnError)
+	dir := flags.String("dir", ".", "directory of the package to describe")
+	output := flags.String("output", "groot_descriptions.go", "name of the generated file, relative to dir")
+	flags.Parse(args)
+
+	outputPath := filepath.Join(*dir, *output)
+	pkg, err := parsePackage(*dir, outputPath)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	err = describeTemplate.Execute(&buf, map[string]interface{}{
+		"Package": pkg.Name,
+		"Types":   describeTypes(pkg),
+	})
+	if err != nil {
+		return err
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(outputPath, src, 0644)
+}
+
+func parsePackage(dir string, outputPath string) (*doc.Package, error) {
+	fset := token.NewFileSet()
+	filter := func(info os.FileInfo) bool {
+		name := info.Name()
+		return !strings.HasSuffix(name, "_test.go") && filepath.Join(dir, name) != outputPath
+	}
+
+	pkgs, err := goparser.ParseDir(fset, dir, filter, goparser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(pkgs) != 1 {
+		return nil, fmt.Errorf("expected exactly one package in %s, found %d", dir, len(pkgs))
+	}
+
+	for _, pkg := range pkgs {
+		return doc.New(pkg, "", doc.AllDecls|doc.PreserveAST), nil
+	}
+
+	return nil, nil
+}
+
+func describeTypes(pkg *doc.Package) []describedType {
+	types := []describedType{}
+
+	for _, t := range pkg.Types {
+		spec, ok := t.Decl.Specs[0].(*ast.TypeSpec)
+		if !ok || spec.Assign.IsValid() {
+			continue
+		}
+
+		described := describedType{
+			Name:        t.Name,
+			Description: strings.TrimSpace(t.Doc),
+			Fields:      describeFields(t, spec),
+			Values:      describeValues(t),
+		}
+
+		if described.Description != "" || len(described.Fields) != 0 || len(described.Values) != 0 {
+			types = append(types, described)
+		}
+	}
+
+	return types
+}
+
+// describeFields returns the doc comments of the fields of a struct, falling
+// back to the doc comment of the field's resolver or subscriber
+func describeFields(t *doc.Type, spec *ast.TypeSpec) []describedValue {
+	fields := []describedValue{}
+	structType, ok := spec.Type.(*ast.StructType)
+	if !ok {
+		return fields
+	}
+
+	methods := map[string]string{}
+	for _, method := range t.Methods {
+		methods[method.Name] = strings.TrimSpace(method.Doc)
+	}
+
+	for _, field := range structType.Fields.List {
+		if isFieldIgnored(field) {
+			continue
+		}
+
+		description := strings.TrimSpace(commentText(field.Doc, field.Comment))
+
+		for _, name := range field.Names {
+			if !name.IsExported() {
+				continue
+			}
+
+			fieldDescription := description
+			if fieldDescription == "" {
+				fieldDescription = methods["Resolve"+name.Name]
+			}
+
+			if fieldDescription == "" {
+				fieldDescription = methods["Subscribe"+name.Name]
+			}
+
+			if fieldDescription != "" {
+				fields = append(fields, describedValue{name.Name, fieldDescription})
+			}
+		}
+	}
+
+	return fields
+}
+
+// describeValues returns the doc comments of the constants of an enum
+func describeValues(t *doc.Type) []describedValue {
+	values := []describedValue{}
+	isEnum := false
+
+	for _, method := range t.Methods {
+		if method.Name == "Values" || method.Name == "EnumValues" {
+			isEnum = true
+		}
+	}
+
+	if !isEnum {
+		return values
+	}
+
+	for _, constant := range t.Consts {
+		for _, spec := range constant.Decl.Specs {
+			spec := spec.(*ast.ValueSpec)
+			description := strings.TrimSpace(commentText(spec.Doc, spec.Comment))
+			if description == "" && len(constant.Decl.Specs) == 1 {
+				description = strings.TrimSpace(constant.Doc)
+			}
+
+			if description == "" {
+				continue
+			}
+
+			for _, name := range spec.Names {
+				if name.Name != "_" {
+					values = append(values, describedValue{name.Name, description})
+				}
+			}
+		}
+	}
+
+	return values
+}
+
+// isFieldIgnored reports whether a field is excluded from the schema, with the
+// same rule the parser uses
+func isFieldIgnored(field *ast.Field) bool {
+	if field.Tag == nil {
+		return false
+	}
+
+	tag, err := strconv.Unquote(field.Tag.Value)
+	if err != nil {
+		return false
+	}
+
+	structTag := reflect.StructTag(tag)
+	if graphqlName := structTag.Get("graphql"); graphqlName != "" {
+		return graphqlName == "-"
+	}
+
+	return structTag.Get("json") == "-"
+}
+
+func commentText(groups ...*ast.CommentGroup) string {
+	for _, group := range groups {
+		if text := group.Text(); text != "" {
+			return text
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "update the golden files")
+
+func TestDescribe(t *testing.T) {
+	dir, err := ioutil.TempDir("", "groot-describe")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	src, err := ioutil.ReadFile(filepath.Join("testdata", "describe", "models.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ioutil.WriteFile(filepath.Join(dir, "models.go"), src, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := runDescribe([]string{"-dir", dir}); err != nil {
+		t.Fatal(err)
+	}
+
+	output, err := ioutil.ReadFile(filepath.Join(dir, "groot_descriptions.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	golden := filepath.Join("testdata", "describe", "groot_descriptions.go.golden")
+	if *update {
+		if err := ioutil.WriteFile(golden, output, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	expected, err := ioutil.ReadFile(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(output) != string(expected) {
+		t.Fatalf("expected:\n%s\ngot:\n%s", expected, output)
+	}
+}
@@ -0,0 +1,44 @@
+// Command groot contains code generators for Groot.
+//
+// Usage:
+//
+//	groot describe [-dir dir] [-output file]
+//
+// describe extracts the doc comments of the types, fields, resolvers and enum
+// values in a package and generates a file registering them as GraphQL
+// descriptions. It's meant to be used with go generate:
+//
+//	//go:generate go run github.com/shreyas44/groot/cmd/groot describe
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+const usage = `usage: groot <command> [arguments]
+
+commands:
+  describe    generate GraphQL descriptions from Go doc comments
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "describe":
+		err = runDescribe(os.Args[2:])
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "groot %s: %s\n", os.Args[1], err)
+		os.Exit(1)
+	}
+}
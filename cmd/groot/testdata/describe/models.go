@@ -0,0 +1,63 @@
+package models
+
+import "github.com/shreyas44/groot"
+
+// User is a registered user
+type User struct {
+	// Base is embedded, so its fields are described on its own type
+	Base
+
+	// ID identifies the user
+	ID    groot.ID `json:"id"`
+	Name  string   `json:"name"` // Name is the display name
+	Posts []Post   `json:"posts"`
+	// Password is never exposed
+	Password string `json:"-"`
+	// Token is never exposed either
+	Token string `json:"token" graphql:"-"`
+	// Email is exposed with the graphql tag despite the json tag
+	Email string `json:"-" graphql:"email"`
+	// secret is unexported
+	secret string
+}
+
+// ResolvePosts returns the posts of the user, newest first
+func (user User) ResolvePosts() ([]Post, error) {
+	return nil, nil
+}
+
+type Base struct {
+	CreatedAt string `json:"createdAt"`
+}
+
+type Post struct {
+	Title string `json:"title"`
+}
+
+// Status is the status of a user
+type Status int
+
+const (
+	// StatusActive users can log in
+	StatusActive Status = iota
+	StatusBanned
+)
+
+func (status Status) EnumValues() []groot.EnumValue {
+	return []groot.EnumValue{
+		{Name: "ACTIVE", Value: StatusActive},
+		{Name: "BANNED", Value: StatusBanned},
+	}
+}
+
+type Subscription struct {
+	UserUpdated User `json:"userUpdated"`
+}
+
+// SubscribeUserUpdated sends a user whenever they're updated
+func (subscription Subscription) SubscribeUserUpdated() (chan User, error) {
+	return nil, nil
+}
+
+// Person is an alias, which is described by the type it aliases
+type Person = User
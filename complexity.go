@@ -0,0 +1,435 @@
+package groot
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/shreyas44/groot/parser"
+)
+
+// DefaultFieldCost is the cost of fields without the cost tag or a cost method
+const DefaultFieldCost = 1
+
+type fieldCost struct {
+	// cost returns the cost of the field given its arguments, by their
+	// GraphQL names
+	cost func(args map[string]interface{}) int
+	// multipliers are the GraphQL names of the arguments with the multiplier
+	// tag, the largest of which the cost of the field is multiplied by
+	multipliers []string
+}
+
+type complexityLimits struct {
+	maxDepth   int
+	maxCost    int
+	fieldCosts map[fieldKey]*fieldCost
+}
+
+// addFieldCost records the cost of a field, which is checked by Executor
+// before a query is executed
+func (builder *SchemaBuilder) addFieldCost(parentType graphql.Type, parserField *parser.Field, field *graphql.Field) {
+	fieldCost := &fieldCost{}
+
+	argsInput := parserField.ArgsInput()
+	if resolver := builder.getResolver(parserField); resolver != nil {
+		argsInput = resolver.ArgsInput()
+	}
+
+	for _, arg := range argsInput.Arguments() {
+		if arg.Multiplier() && builder.isArgumentVisible(arg) {
+			fieldCost.multipliers = append(fieldCost.multipliers, builder.argumentName(arg))
+		}
+	}
+
+	cost, hasCost := parserField.Cost()
+	costMethod := parserField.CostMethod()
+	switch {
+	case costMethod != nil:
+		builder.checkCostMethodArgs(argsInput, costMethod)
+		fieldCost.cost = builder.newCostMethodFunc(argsInput, costMethod)
+	case hasCost:
+		fieldCost.cost = func(map[string]interface{}) int { return cost }
+	default:
+		fieldCost.cost = func(map[string]interface{}) int { return DefaultFieldCost }
+	}
+
+	builder.fieldCosts[fieldKey{parentType, field.Name}] = fieldCost
+}
+
+// checkCostMethodArgs checks the arguments a Cost<Field> method accepts are the
+// arguments of the resolver of the field, which the parser cannot check for
+// resolvers from resolver sets
+func (builder *SchemaBuilder) checkCostMethodArgs(argsInput *parser.Input, costMethod *parser.CostMethod) {
+	method := costMethod.ReflectMethod()
+	if !costMethod.AcceptsArgs() || (argsInput != nil && method.Type.In(1) == argsInput.ReflectType()) {
+		return
+	}
+
+	argsType := "no arguments"
+	if argsInput != nil {
+		argsType = fmt.Sprintf("no arguments or 1 argument of type (%s)", argsInput.ReflectType())
+	}
+
+	builder.addError(fmt.Errorf(
+		"method %s on struct %s expected to have %s",
+		method.Name,
+		costMethod.Field().Object().ReflectType().Name(),
+		argsType,
+	))
+}
+
+// newCostMethodFunc returns a function which calls the Cost<Field> method of a
+// field on the zero value of the object, with the arguments of the field
+func (builder *SchemaBuilder) newCostMethodFunc(argsInput *parser.Input, costMethod *parser.CostMethod) func(map[string]interface{}) int {
+	method := costMethod.ReflectMethod()
+	funcType := method.Type
+
+	return func(args map[string]interface{}) int {
+		receiverType := funcType.In(0)
+		receiver := reflect.Zero(receiverType)
+		if receiverType.Kind() == reflect.Ptr {
+			receiver = reflect.New(receiverType.Elem())
+		}
+
+		in := []reflect.Value{receiver}
+		if costMethod.AcceptsArgs() {
+			// TODO: same as resolver arguments, avoid marshalling and unmarshalling
+			argsValue := reflect.New(funcType.In(1))
+			jsonArgs := builder.argsToJSON(argsInput, args)
+			if jsonBytes, err := json.Marshal(jsonArgs); err == nil {
+				json.Unmarshal(jsonBytes, argsValue.Interface())
+			}
+
+			in = append(in, argsValue.Elem())
+		}
+
+		return int(method.Func.Call(in)[0].Int())
+	}
+}
+
+// newComplexityLimits returns the limits Executor checks operations against,
+// or nil if neither limit is set
+func (builder *SchemaBuilder) newComplexityLimits(maxDepth, maxCost int) *complexityLimits {
+	if maxDepth <= 0 && maxCost <= 0 {
+		return nil
+	}
+
+	return &complexityLimits{
+		maxDepth:   maxDepth,
+		maxCost:    maxCost,
+		fieldCosts: builder.fieldCosts,
+	}
+}
+
+// check returns an error for the operation of a request if it exceeds the
+// limits. It's run for every request instead of with the cached validation,
+// since the cost depends on the values of variables.
+func (limits *complexityLimits) check(schema *graphql.Schema, document *ast.Document, operationName string, variables map[string]interface{}) []gqlerrors.FormattedError {
+	operation := getOperation(document, operationName)
+	if operation == nil {
+		// graphql.Execute reports a missing or ambiguous operation
+		return nil
+	}
+
+	c := &complexity{
+		schema:         schema,
+		limits:         limits,
+		fragments:      map[string]*ast.FragmentDefinition{},
+		variableDefs:   operation.VariableDefinitions,
+		variables:      variables,
+		visitFragments: map[string]bool{},
+	}
+
+	for _, definition := range document.Definitions {
+		if fragment, ok := definition.(*ast.FragmentDefinition); ok {
+			c.fragments[fragment.Name.Value] = fragment
+		}
+	}
+
+	rootType := getOperationRootType(schema, operation)
+	depth, cost := c.selectionSet(rootType, operation.SelectionSet, 1)
+
+	errs := []gqlerrors.FormattedError{}
+	if limits.maxDepth > 0 && depth > limits.maxDepth {
+		errs = append(errs, newComplexityError(
+			fmt.Sprintf("%s has a depth of %d, which exceeds the maximum depth of %d", operationString(operation), depth, limits.maxDepth),
+			operation,
+		))
+	}
+
+	if limits.maxCost > 0 && cost > limits.maxCost {
+		errs = append(errs, newComplexityError(
+			fmt.Sprintf("%s has a cost of %d, which exceeds the maximum cost of %d", operationString(operation), cost, limits.maxCost),
+			operation,
+		))
+	}
+
+	return errs
+}
+
+func newComplexityError(message string, operation *ast.OperationDefinition) gqlerrors.FormattedError {
+	return gqlerrors.FormatError(gqlerrors.NewError(
+		message,
+		[]ast.Node{operation},
+		"",
+		nil,
+		[]int{},
+		nil,
+	))
+}
+
+// getOperation returns the operation of a document with the given name, or the
+// only operation if the name is empty
+func getOperation(document *ast.Document, operationName string) *ast.OperationDefinition {
+	var operation *ast.OperationDefinition
+	for _, definition := range document.Definitions {
+		definition, ok := definition.(*ast.OperationDefinition)
+		if !ok {
+			continue
+		}
+
+		if operationName == "" {
+			if operation != nil {
+				return nil
+			}
+
+			operation = definition
+		} else if definition.Name != nil && definition.Name.Value == operationName {
+			return definition
+		}
+	}
+
+	return operation
+}
+
+type complexity struct {
+	schema       *graphql.Schema
+	limits       *complexityLimits
+	fragments    map[string]*ast.FragmentDefinition
+	variableDefs []*ast.VariableDefinition
+	variables    map[string]interface{}
+	// visitFragments are the fragments being visited, to stop at fragments
+	// which spread themselves, which are reported by another rule
+	visitFragments map[string]bool
+}
+
+// selectionSet returns the depth and the cost of a selection set, where depth
+// is the depth of the fields in it
+func (c *complexity) selectionSet(parentType graphql.Type, selectionSet *ast.SelectionSet, depth int) (int, int) {
+	if parentType == nil || selectionSet == nil {
+		return 0, 0
+	}
+
+	maxDepth, totalCost := 0, 0
+	for _, selection := range selectionSet.Selections {
+		var selectionDepth, selectionCost int
+		switch selection := selection.(type) {
+		case *ast.Field:
+			selectionDepth, selectionCost = c.field(parentType, selection, depth)
+
+		case *ast.InlineFragment:
+			fragmentType := parentType
+			if selection.TypeCondition != nil {
+				fragmentType = c.schema.Type(selection.TypeCondition.Name.Value)
+			}
+
+			selectionDepth, selectionCost = c.selectionSet(fragmentType, selection.SelectionSet, depth)
+
+		case *ast.FragmentSpread:
+			name := selection.Name.Value
+			fragment := c.fragments[name]
+			if fragment == nil || fragment.TypeCondition == nil || c.visitFragments[name] {
+				continue
+			}
+
+			fragmentType := c.schema.Type(fragment.TypeCondition.Name.Value)
+			c.visitFragments[name] = true
+			selectionDepth, selectionCost = c.selectionSet(fragmentType, fragment.SelectionSet, depth)
+			delete(c.visitFragments, name)
+		}
+
+		if selectionDepth > maxDepth {
+			maxDepth = selectionDepth
+		}
+
+		totalCost = addCost(totalCost, selectionCost)
+	}
+
+	return maxDepth, totalCost
+}
+
+func (c *complexity) field(parentType graphql.Type, fieldAST *ast.Field, depth int) (int, int) {
+	name := fieldAST.Name.Value
+
+	// introspection fields are left out of the depth and cost
+	if strings.HasPrefix(name, "__") {
+		return 0, 0
+	}
+
+	var fieldDef *graphql.FieldDefinition
+	switch parentType := parentType.(type) {
+	case *graphql.Object:
+		fieldDef = parentType.Fields()[name]
+	case *graphql.Interface:
+		fieldDef = parentType.Fields()[name]
+	}
+
+	// unknown fields are reported by another rule
+	if fieldDef == nil {
+		return 0, 0
+	}
+
+	fieldType, _ := graphql.GetNamed(fieldDef.Type).(graphql.Type)
+	childDepth, childCost := c.selectionSet(fieldType, fieldAST.SelectionSet, depth+1)
+	if childDepth < depth {
+		childDepth = depth
+	}
+
+	fieldCost, ok := c.limits.fieldCosts[fieldKey{parentType, name}]
+	if !ok {
+		return childDepth, addCost(DefaultFieldCost, childCost)
+	}
+
+	args := c.arguments(fieldDef, fieldAST)
+	cost := addCost(fieldCost.cost(args), childCost)
+
+	multiplier := 1
+	for _, name := range fieldCost.multipliers {
+		if value, ok := toInt(args[name]); ok && value > multiplier {
+			multiplier = value
+		}
+	}
+
+	return childDepth, multiplyCost(cost, multiplier)
+}
+
+// arguments returns the values of the arguments of a field, by their GraphQL
+// names. Arguments passed as variables use the value of the variable in the
+// request, the default value of the variable if it isn't set, or the default
+// value of the argument if the variable has neither.
+func (c *complexity) arguments(fieldDef *graphql.FieldDefinition, fieldAST *ast.Field) map[string]interface{} {
+	args := map[string]interface{}{}
+	for _, arg := range fieldDef.Args {
+		if arg.DefaultValue != nil {
+			args[arg.Name()] = arg.DefaultValue
+		}
+	}
+
+	for _, argAST := range fieldAST.Arguments {
+		var argDef *graphql.Argument
+		for _, arg := range fieldDef.Args {
+			if arg.Name() == argAST.Name.Value {
+				argDef = arg
+			}
+		}
+
+		if argDef == nil {
+			continue
+		}
+
+		if value, ok := c.argumentValue(argAST.Value, argDef.Type); ok {
+			args[argDef.Name()] = value
+		}
+	}
+
+	return args
+}
+
+// argumentValue returns the value of an argument, resolving variables
+func (c *complexity) argumentValue(valueAST ast.Value, argType graphql.Input) (interface{}, bool) {
+	variable, ok := valueAST.(*ast.Variable)
+	if !ok {
+		value, err := literalToValue(valueAST, argType)
+		return value, err == nil
+	}
+
+	name := variable.Name.Value
+	if value, ok := c.variables[name]; ok {
+		return value, true
+	}
+
+	for _, definition := range c.variableDefs {
+		if definition.Variable.Name.Value == name && definition.DefaultValue != nil {
+			value, err := literalToValue(definition.DefaultValue, argType)
+			return value, err == nil
+		}
+	}
+
+	return nil, false
+}
+
+// toInt converts the value of a multiplier to an int. Variables decoded from
+// JSON are float64 or json.Number rather than int.
+func toInt(value interface{}) (int, bool) {
+	switch value := value.(type) {
+	case int:
+		return value, true
+	case int32:
+		return int(value), true
+	case int64:
+		return int(value), true
+	case float64:
+		if value >= float64(maxInt) {
+			return maxInt, true
+		}
+
+		return int(value), true
+	case json.Number:
+		i, err := value.Int64()
+		return int(i), err == nil
+	}
+
+	return 0, false
+}
+
+func getOperationRootType(schema *graphql.Schema, operation *ast.OperationDefinition) graphql.Type {
+	var rootType *graphql.Object
+	switch operation.Operation {
+	case ast.OperationTypeQuery:
+		rootType = schema.QueryType()
+	case ast.OperationTypeMutation:
+		rootType = schema.MutationType()
+	case ast.OperationTypeSubscription:
+		rootType = schema.SubscriptionType()
+	}
+
+	// avoid returning a nil *graphql.Object as a non nil graphql.Type
+	if rootType == nil {
+		return nil
+	}
+
+	return rootType
+}
+
+func operationString(operation *ast.OperationDefinition) string {
+	if operation.Name == nil {
+		return operation.Operation
+	}
+
+	return fmt.Sprintf("%s %s", operation.Operation, operation.Name.Value)
+}
+
+// addCost and multiplyCost cap costs at the largest int instead of
+// overflowing, since multipliers come from clients
+const maxInt = int(^uint(0) >> 1)
+
+func addCost(a, b int) int {
+	if a > maxInt-b {
+		return maxInt
+	}
+
+	return a + b
+}
+
+func multiplyCost(cost, multiplier int) int {
+	if cost != 0 && multiplier > maxInt/cost {
+		return maxInt
+	}
+
+	return cost * multiplier
+}
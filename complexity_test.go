@@ -0,0 +1,157 @@
+package groot_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/shreyas44/groot"
+	"github.com/shreyas44/groot/parser"
+)
+
+type ComplexityItem struct {
+	Name string `json:"name"`
+}
+
+type ComplexityItemsArgs struct {
+	First *int `json:"first" multiplier:"true"`
+}
+
+type ComplexityQuery struct {
+	Items []ComplexityItem `json:"items"`
+}
+
+func (query ComplexityQuery) ResolveItems(args ComplexityItemsArgs) ([]ComplexityItem, error) {
+	return []ComplexityItem{}, nil
+}
+
+func newComplexityExecutor(t *testing.T) *groot.Executor {
+	executor, err := groot.NewExecutor(groot.SchemaConfig{
+		Query: groot.MustParseObject(ComplexityQuery{}),
+	}, groot.ExecutorConfig{MaxCost: 100})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return executor
+}
+
+func assertCostError(t *testing.T, executor *groot.Executor, request groot.Request) {
+	t.Helper()
+	result := executor.Execute(request)
+	if len(result.Errors) != 1 || !strings.Contains(result.Errors[0].Message, "exceeds the maximum cost of 100") {
+		t.Fatalf("expected a cost error, got %v", result.Errors)
+	}
+}
+
+func TestComplexityLimitsLiteralMultipliers(t *testing.T) {
+	executor := newComplexityExecutor(t)
+
+	assertResult(t, executor.Execute(groot.Request{Query: "{ items(first: 10) { name } }"}), `{"items":[]}`)
+	assertCostError(t, executor, groot.Request{Query: "{ items(first: 1000) { name } }"})
+}
+
+func TestComplexityLimitsVariableMultipliers(t *testing.T) {
+	executor := newComplexityExecutor(t)
+	query := "query Items($first: Int) { items(first: $first) { name } }"
+
+	// the document is cached by the first request, the cost must still be
+	// checked with the variables of every request
+	assertResult(t, executor.Execute(groot.Request{
+		Query:     query,
+		Variables: map[string]interface{}{"first": 10},
+	}), `{"items":[]}`)
+
+	assertCostError(t, executor, groot.Request{
+		Query:     query,
+		Variables: map[string]interface{}{"first": 1000},
+	})
+
+	// variables decoded from JSON are float64
+	assertCostError(t, executor, groot.Request{
+		Query:     query,
+		Variables: map[string]interface{}{"first": float64(1000)},
+	})
+}
+
+func TestComplexityLimitsVariableDefaults(t *testing.T) {
+	executor := newComplexityExecutor(t)
+	assertCostError(t, executor, groot.Request{
+		Query: "query Items($first: Int = 1000) { items(first: $first) { name } }",
+	})
+}
+
+type ComplexityPostsArgs struct {
+	First int `json:"first"`
+}
+
+type ComplexityUser struct {
+	Posts []ComplexityItem `json:"posts"`
+}
+
+func (user ComplexityUser) CostPosts(args ComplexityPostsArgs) int {
+	return args.First
+}
+
+type ComplexityUserQuery struct {
+	User ComplexityUser `json:"user"`
+}
+
+func (query ComplexityUserQuery) ResolveUser() (ComplexityUser, error) {
+	return ComplexityUser{}, nil
+}
+
+type ComplexityUserResolvers struct{}
+
+func (resolvers ComplexityUserResolvers) ResolvePosts(user ComplexityUser, args ComplexityPostsArgs) ([]ComplexityItem, error) {
+	return []ComplexityItem{}, nil
+}
+
+func TestComplexityLimitsCostMethodWithResolverSet(t *testing.T) {
+	executor, err := groot.NewExecutor(groot.SchemaConfig{
+		Query:     groot.MustParseObject(ComplexityUserQuery{}),
+		Resolvers: []*parser.ResolverSet{groot.MustParseResolvers(ComplexityUserResolvers{})},
+	}, groot.ExecutorConfig{MaxCost: 100})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the arguments of the field are defined by the resolver set, which the
+	// cost method must be called with
+	assertResult(t, executor.Execute(groot.Request{Query: "{ user { posts(first: 10) { name } } }"}), `{"user":{"posts":[]}}`)
+	assertCostError(t, executor, groot.Request{Query: "{ user { posts(first: 1000) { name } } }"})
+}
+
+type ComplexityOtherArgs struct {
+	Last int `json:"last"`
+}
+
+type ComplexityOtherUser struct {
+	Posts []ComplexityItem `json:"posts"`
+}
+
+func (user ComplexityOtherUser) CostPosts(args ComplexityOtherArgs) int {
+	return args.Last
+}
+
+type ComplexityOtherUserQuery struct {
+	User ComplexityOtherUser `json:"user"`
+}
+
+type ComplexityOtherUserResolvers struct{}
+
+func (resolvers ComplexityOtherUserResolvers) ResolvePosts(user ComplexityOtherUser, args ComplexityPostsArgs) ([]ComplexityItem, error) {
+	return []ComplexityItem{}, nil
+}
+
+func TestComplexityLimitsCostMethodArgsMismatch(t *testing.T) {
+	_, err := groot.NewExecutor(groot.SchemaConfig{
+		Query:     groot.MustParseObject(ComplexityOtherUserQuery{}),
+		Resolvers: []*parser.ResolverSet{groot.MustParseResolvers(ComplexityOtherUserResolvers{})},
+	}, groot.ExecutorConfig{MaxCost: 100})
+
+	if err == nil || !strings.Contains(err.Error(), "method CostPosts on struct ComplexityOtherUser") {
+		t.Fatalf("expected an error for the arguments of the cost method, got %v", err)
+	}
+}
@@ -0,0 +1,155 @@
+package groot
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+type dependencyProvider func(ctx context.Context) (reflect.Value, error)
+
+type contextDependenciesKey struct{}
+
+// Container provides dependencies to resolvers, which can accept parameters
+// of any type other than their arguments, context.Context and
+// graphql.ResolveInfo. A parameter of an interface type is provided by the
+// first registered type implementing it if no provider is registered for the
+// interface itself.
+type Container struct {
+	providers map[reflect.Type]dependencyProvider
+	types     []reflect.Type
+}
+
+func NewContainer() *Container {
+	return &Container{
+		providers: map[reflect.Type]dependencyProvider{},
+		types:     []reflect.Type{},
+	}
+}
+
+func (c *Container) addProvider(t reflect.Type, provider dependencyProvider) {
+	if _, ok := c.providers[t]; !ok {
+		c.types = append(c.types, t)
+	}
+
+	c.providers[t] = provider
+}
+
+// Provide registers a value, like a database handle or a logger, for
+// resolvers accepting a parameter of its type
+func (c *Container) Provide(v interface{}) {
+	if v == nil {
+		panic("groot: cannot provide a nil dependency")
+	}
+
+	value := reflect.ValueOf(v)
+	c.addProvider(value.Type(), func(ctx context.Context) (reflect.Value, error) {
+		return value, nil
+	})
+}
+
+// ProvideFunc registers a function of type func(context.Context) (T, error)
+// which is called on every resolver call accepting a parameter of type T
+func (c *Container) ProvideFunc(fn interface{}) {
+	var (
+		fnValue          = reflect.ValueOf(fn)
+		fnType           = fnValue.Type()
+		contextInterface = reflect.TypeOf((*context.Context)(nil)).Elem()
+		errorInterface   = reflect.TypeOf((*error)(nil)).Elem()
+	)
+
+	if fnType.Kind() != reflect.Func ||
+		fnType.NumIn() != 1 || fnType.In(0) != contextInterface ||
+		fnType.NumOut() != 2 || fnType.Out(1) != errorInterface {
+		panic(fmt.Sprintf("groot: provider should be of type func(context.Context) (T, error), got %s", fnType))
+	}
+
+	c.addProvider(fnType.Out(0), func(ctx context.Context) (reflect.Value, error) {
+		if ctx == nil {
+			ctx = context.Background()
+		}
+
+		res := fnValue.Call([]reflect.Value{reflect.ValueOf(&ctx).Elem()})
+		if err := res[1]; !err.IsNil() {
+			return reflect.Value{}, err.Interface().(error)
+		}
+
+		return res[0], nil
+	})
+}
+
+// ProvideFromContext declares that values of type t, like a per request
+// session, are added to the request context with WithDependency
+func (c *Container) ProvideFromContext(t reflect.Type) {
+	c.addProvider(t, func(ctx context.Context) (reflect.Value, error) {
+		if value, ok := dependencyFromContext(ctx, t); ok {
+			return value, nil
+		}
+
+		return reflect.Value{}, fmt.Errorf("no dependency of type %s in the request context", t)
+	})
+}
+
+func (c *Container) getProvider(t reflect.Type) (dependencyProvider, bool) {
+	if c == nil {
+		return nil, false
+	}
+
+	if provider, ok := c.providers[t]; ok {
+		return provider, true
+	}
+
+	if t.Kind() != reflect.Interface {
+		return nil, false
+	}
+
+	for _, providedType := range c.types {
+		if providedType.Implements(t) {
+			return c.providers[providedType], true
+		}
+	}
+
+	return nil, false
+}
+
+// WithDependency returns a copy of ctx with v added as a dependency, to be
+// provided to resolvers for types registered with Container.ProvideFromContext
+func WithDependency(ctx context.Context, v interface{}) context.Context {
+	dependencies := map[reflect.Type]reflect.Value{}
+	if existing, ok := ctx.Value(contextDependenciesKey{}).(map[reflect.Type]reflect.Value); ok {
+		for t, value := range existing {
+			dependencies[t] = value
+		}
+	}
+
+	value := reflect.ValueOf(v)
+	dependencies[value.Type()] = value
+	return context.WithValue(ctx, contextDependenciesKey{}, dependencies)
+}
+
+func dependencyFromContext(ctx context.Context, t reflect.Type) (reflect.Value, bool) {
+	if ctx == nil {
+		return reflect.Value{}, false
+	}
+
+	dependencies, ok := ctx.Value(contextDependenciesKey{}).(map[reflect.Type]reflect.Value)
+	if !ok {
+		return reflect.Value{}, false
+	}
+
+	if value, ok := dependencies[t]; ok {
+		return value, true
+	}
+
+	if t.Kind() != reflect.Interface {
+		return reflect.Value{}, false
+	}
+
+	for valueType, value := range dependencies {
+		if valueType.Implements(t) {
+			return value, true
+		}
+	}
+
+	return reflect.Value{}, false
+}
@@ -0,0 +1,133 @@
+package groot_test
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/shreyas44/groot"
+)
+
+type ContainerDB struct {
+	name string
+}
+
+type ContainerGreeter interface {
+	Greet(name string) string
+}
+
+type ContainerEnglishGreeter struct{}
+
+func (greeter ContainerEnglishGreeter) Greet(name string) string {
+	return "hello " + name
+}
+
+type ContainerRequestID string
+
+type ContainerSession struct {
+	User string
+}
+
+type ContainerConfig struct {
+	Suffix string
+}
+
+type ContainerArgs struct {
+	Name string `json:"name"`
+}
+
+type ContainerQuery struct {
+	DB       string  `json:"db"`
+	Greeting string  `json:"greeting"`
+	Request  string  `json:"request"`
+	User     *string `json:"user"`
+	Config   string  `json:"config"`
+}
+
+func (query ContainerQuery) ResolveDB(db *ContainerDB) (string, error) {
+	return db.name, nil
+}
+
+// ResolveGreeting accepts an interface provided by the type implementing it,
+// after a struct which is the arguments of the field
+func (query ContainerQuery) ResolveGreeting(args ContainerArgs, greeter ContainerGreeter) (string, error) {
+	return greeter.Greet(args.Name), nil
+}
+
+func (query ContainerQuery) ResolveRequest(ctx context.Context, id ContainerRequestID) (string, error) {
+	return string(id), nil
+}
+
+func (query ContainerQuery) ResolveUser(session *ContainerSession) (*string, error) {
+	return &session.User, nil
+}
+
+// ResolveConfig accepts a struct after the context, which is a dependency
+// since only a struct in the first parameter is the arguments
+func (query ContainerQuery) ResolveConfig(ctx context.Context, config ContainerConfig) (string, error) {
+	return "config" + config.Suffix, nil
+}
+
+func newContainer() *groot.Container {
+	container := groot.NewContainer()
+	container.Provide(&ContainerDB{name: "db"})
+	container.Provide(ContainerEnglishGreeter{})
+	container.Provide(ContainerConfig{Suffix: "!"})
+	container.ProvideFunc(func(ctx context.Context) (ContainerRequestID, error) {
+		return "request", nil
+	})
+	container.ProvideFromContext(reflect.TypeOf(&ContainerSession{}))
+	return container
+}
+
+func TestContainer(t *testing.T) {
+	schema, err := groot.NewSchema(groot.SchemaConfig{
+		Query:     groot.MustParseObject(ContainerQuery{}),
+		Container: newContainer(),
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := groot.WithDependency(context.Background(), &ContainerSession{User: "alice"})
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ db greeting(name: "bob") request user config }`,
+		Context:       ctx,
+	})
+
+	assertResult(t, result, `{"config":"config!","db":"db","greeting":"hello bob","request":"request","user":"alice"}`)
+}
+
+func TestContainerDependencyMissingFromContext(t *testing.T) {
+	schema, err := groot.NewSchema(groot.SchemaConfig{
+		Query:     groot.MustParseObject(ContainerQuery{}),
+		Container: newContainer(),
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := graphql.Do(graphql.Params{Schema: schema, RequestString: `{ user }`, Context: context.Background()})
+	if len(result.Errors) != 1 || !strings.Contains(result.Errors[0].Message, "no dependency of type *groot_test.ContainerSession") {
+		t.Fatalf("expected an error for the missing dependency, got %v", result.Errors)
+	}
+}
+
+func TestContainerMissingProvider(t *testing.T) {
+	container := groot.NewContainer()
+	container.Provide(&ContainerDB{name: "db"})
+
+	_, err := groot.NewSchema(groot.SchemaConfig{
+		Query:     groot.MustParseObject(ContainerQuery{}),
+		Container: container,
+	})
+
+	if err == nil || !strings.Contains(err.Error(), "no provider for parameter of type") {
+		t.Fatalf("expected an error for a parameter without a provider, got %v", err)
+	}
+}
@@ -0,0 +1,293 @@
+package groot
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/kinds"
+	gqlparser "github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/printer"
+)
+
+// parseDefaultValue parses the default value of an argument, written as a
+// GraphQL literal, into the value graphql-go passes to resolvers. Values of
+// String and ID arguments can be written without quotes.
+func parseDefaultValue(defaultValue string, t graphql.Input) (interface{}, error) {
+	if isStringType(t) && !strings.HasPrefix(strings.TrimSpace(defaultValue), `"`) {
+		return defaultValue, nil
+	}
+
+	valueAST, err := parseLiteral(defaultValue)
+	if err != nil {
+		return nil, err
+	}
+
+	return literalToValue(valueAST, t)
+}
+
+func isStringType(t graphql.Type) bool {
+	t = graphql.GetNullable(t).(graphql.Type)
+	return t == graphql.String || t == graphql.ID
+}
+
+// parseLiteral parses a GraphQL literal by parsing it as the argument of a
+// field in a query, since graphql-go doesn't expose a parser for values
+func parseLiteral(literal string) (ast.Value, error) {
+	doc, err := gqlparser.Parse(gqlparser.ParseParams{
+		Source: fmt.Sprintf("{ f(v: %s) }", literal),
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("couldn't parse %s as a GraphQL value", literal)
+	}
+
+	var (
+		operation = doc.Definitions[0].(*ast.OperationDefinition)
+		field     = operation.SelectionSet.Selections[0].(*ast.Field)
+	)
+
+	if len(field.Arguments) != 1 {
+		return nil, fmt.Errorf("couldn't parse %s as a GraphQL value", literal)
+	}
+
+	value := field.Arguments[0].Value
+	if value.GetKind() == kinds.Variable {
+		return nil, fmt.Errorf("default value %s cannot be a variable", literal)
+	}
+
+	return value, nil
+}
+
+// literalToValue converts a literal to the value of the given type,
+// validating it in the process
+func literalToValue(valueAST ast.Value, t graphql.Input) (value interface{}, err error) {
+	switch t := t.(type) {
+	case *graphql.NonNull:
+		return literalToValue(valueAST, t.OfType)
+
+	case *graphql.List:
+		listAST, ok := valueAST.(*ast.ListValue)
+		if !ok {
+			// a single value is accepted as a list with one value
+			item, err := literalToValue(valueAST, t.OfType)
+			if err != nil {
+				return nil, err
+			}
+
+			return []interface{}{item}, nil
+		}
+
+		list := []interface{}{}
+		for _, itemAST := range listAST.Values {
+			item, err := literalToValue(itemAST, t.OfType)
+			if err != nil {
+				return nil, err
+			}
+
+			list = append(list, item)
+		}
+
+		return list, nil
+
+	case *graphql.InputObject:
+		objectAST, ok := valueAST.(*ast.ObjectValue)
+		if !ok {
+			return nil, fmt.Errorf("expected an object for input %s, got %s", t.Name(), printer.Print(valueAST))
+		}
+
+		fields := t.Fields()
+		object := map[string]interface{}{}
+		for _, fieldAST := range objectAST.Fields {
+			field, ok := fields[fieldAST.Name.Value]
+			if !ok {
+				return nil, fmt.Errorf("field %s is not defined on input %s", fieldAST.Name.Value, t.Name())
+			}
+
+			fieldValue, err := literalToValue(fieldAST.Value, field.Type)
+			if err != nil {
+				return nil, err
+			}
+
+			object[field.Name()] = fieldValue
+		}
+
+		for name, field := range fields {
+			if _, ok := object[name]; ok {
+				continue
+			}
+
+			if field.DefaultValue != nil {
+				object[name] = field.DefaultValue
+			} else if _, isNonNull := field.Type.(*graphql.NonNull); isNonNull {
+				return nil, fmt.Errorf("field %s on input %s is required", name, t.Name())
+			}
+		}
+
+		return object, nil
+
+	case *graphql.Scalar, *graphql.Enum:
+		// custom scalars panic when the value can't be parsed
+		defer func() {
+			if r := recover(); r != nil {
+				value, err = nil, fmt.Errorf("invalid value %s for %s: %v", printer.Print(valueAST), t.(graphql.Type).Name(), r)
+			}
+		}()
+
+		leaf := t.(interface{ ParseLiteral(ast.Value) interface{} })
+		if value = leaf.ParseLiteral(valueAST); value == nil {
+			return nil, fmt.Errorf("invalid value %s for %s", printer.Print(valueAST), t.(graphql.Type).Name())
+		}
+
+		return value, nil
+	}
+
+	return nil, fmt.Errorf("unexpected type %s", t)
+}
+
+// printDefaultValue prints a default value as a GraphQL literal of its type.
+// graphql-go prints enum values and input objects as strings.
+func printDefaultValue(value interface{}, t graphql.Type) interface{} {
+	if value == nil {
+		return nil
+	}
+
+	valueAST := valueToAST(value, t)
+	if valueAST == nil {
+		return nil
+	}
+
+	return printer.Print(valueAST)
+}
+
+// valueToAST converts a value graphql-go passes to resolvers back to a literal
+// of the given type
+func valueToAST(value interface{}, t graphql.Type) ast.Value {
+	if value == nil {
+		return nil
+	}
+
+	switch t := t.(type) {
+	case *graphql.NonNull:
+		return valueToAST(value, t.OfType)
+
+	case *graphql.List:
+		list, ok := value.([]interface{})
+		if !ok {
+			return valueToAST(value, t.OfType)
+		}
+
+		values := []ast.Value{}
+		for _, item := range list {
+			if itemAST := valueToAST(item, t.OfType); itemAST != nil {
+				values = append(values, itemAST)
+			}
+		}
+
+		return ast.NewListValue(&ast.ListValue{Values: values})
+
+	case *graphql.InputObject:
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+
+		names := []string{}
+		for name := range object {
+			names = append(names, name)
+		}
+
+		sort.Strings(names)
+		fields := []*ast.ObjectField{}
+		for _, name := range names {
+			field, ok := t.Fields()[name]
+			if !ok {
+				continue
+			}
+
+			if fieldAST := valueToAST(object[name], field.Type); fieldAST != nil {
+				fields = append(fields, ast.NewObjectField(&ast.ObjectField{
+					Name:  ast.NewName(&ast.Name{Value: name}),
+					Value: fieldAST,
+				}))
+			}
+		}
+
+		return ast.NewObjectValue(&ast.ObjectValue{Fields: fields})
+
+	case *graphql.Enum:
+		name, ok := t.Serialize(value).(string)
+		if !ok {
+			return nil
+		}
+
+		return ast.NewEnumValue(&ast.EnumValue{Value: name})
+
+	case *graphql.Scalar:
+		return jsonToAST(t.Serialize(value))
+	}
+
+	return nil
+}
+
+// jsonToAST converts a serialized scalar to a literal by the JSON
+// representation of the value
+func jsonToAST(value interface{}) ast.Value {
+	jsonRepr, err := json.Marshal(value)
+	if err != nil {
+		return nil
+	}
+
+	var jsonValue interface{}
+	decoder := json.NewDecoder(bytes.NewReader(jsonRepr))
+	decoder.UseNumber()
+	if err := decoder.Decode(&jsonValue); err != nil {
+		return nil
+	}
+
+	switch jsonValue := jsonValue.(type) {
+	case string:
+		return ast.NewStringValue(&ast.StringValue{Value: jsonValue})
+	case bool:
+		return ast.NewBooleanValue(&ast.BooleanValue{Value: jsonValue})
+	case json.Number:
+		if strings.ContainsAny(string(jsonValue), ".eE") {
+			return ast.NewFloatValue(&ast.FloatValue{Value: string(jsonValue)})
+		}
+
+		return ast.NewIntValue(&ast.IntValue{Value: string(jsonValue)})
+	case []interface{}:
+		values := []ast.Value{}
+		for _, item := range jsonValue {
+			if itemAST := jsonToAST(item); itemAST != nil {
+				values = append(values, itemAST)
+			}
+		}
+
+		return ast.NewListValue(&ast.ListValue{Values: values})
+	case map[string]interface{}:
+		names := []string{}
+		for name := range jsonValue {
+			names = append(names, name)
+		}
+
+		sort.Strings(names)
+		fields := []*ast.ObjectField{}
+		for _, name := range names {
+			if fieldAST := jsonToAST(jsonValue[name]); fieldAST != nil {
+				fields = append(fields, ast.NewObjectField(&ast.ObjectField{
+					Name:  ast.NewName(&ast.Name{Value: name}),
+					Value: fieldAST,
+				}))
+			}
+		}
+
+		return ast.NewObjectValue(&ast.ObjectValue{Fields: fields})
+	}
+
+	return nil
+}
@@ -0,0 +1,152 @@
+package groot_test
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/shreyas44/groot"
+)
+
+type DefaultValueArgs struct {
+	First *int `json:"first" default:"10"`
+}
+
+type DefaultValueQuery struct {
+	Items int `json:"items"`
+}
+
+func (query DefaultValueQuery) ResolveItems(args DefaultValueArgs) (int, error) {
+	return *args.First, nil
+}
+
+type DefaultValueStatus int
+
+const (
+	DefaultValueStatusActive DefaultValueStatus = iota
+	DefaultValueStatusBanned
+)
+
+func (status *DefaultValueStatus) EnumValues() []groot.EnumValue {
+	return []groot.EnumValue{
+		{Name: "ACTIVE", Value: DefaultValueStatusActive},
+		{Name: "BANNED", Value: DefaultValueStatusBanned},
+	}
+}
+
+type DefaultValueFilter struct {
+	Limit int `json:"limit"`
+}
+
+type NonNullDefaultValueArgs struct {
+	First  int                `json:"first" default:"10"`
+	Status DefaultValueStatus `json:"status" default:"BANNED"`
+	Filter DefaultValueFilter `json:"filter" default:"{limit: 3}"`
+}
+
+type NonNullDefaultValueQuery struct {
+	Items string `json:"items"`
+}
+
+func (query NonNullDefaultValueQuery) ResolveItems(args NonNullDefaultValueArgs) (string, error) {
+	return fmt.Sprintf("%d %d %d", args.First, args.Status, args.Filter.Limit), nil
+}
+
+func TestDefaultValue(t *testing.T) {
+	schema, err := groot.NewSchema(groot.SchemaConfig{
+		Query: groot.MustParseObject(DefaultValueQuery{}),
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := graphql.Do(graphql.Params{Schema: schema, RequestString: "{ items }"})
+	assertResult(t, result, `{"items":10}`)
+}
+
+func TestDefaultValueOnNonNullArgument(t *testing.T) {
+	schema, err := groot.NewSchema(groot.SchemaConfig{
+		Query: groot.MustParseObject(NonNullDefaultValueQuery{}),
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := graphql.Do(graphql.Params{Schema: schema, RequestString: "{ items }"})
+	assertResult(t, result, `{"items":"10 1 3"}`)
+
+	result = graphql.Do(graphql.Params{Schema: schema, RequestString: "{ items(first: 5, status: ACTIVE, filter: {limit: 1}) }"})
+	assertResult(t, result, `{"items":"5 0 1"}`)
+}
+
+func TestDefaultValueIntrospection(t *testing.T) {
+	schema, err := groot.NewSchema(groot.SchemaConfig{
+		Query: groot.MustParseObject(NonNullDefaultValueQuery{}),
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ __type(name: "NonNullDefaultValueQuery") { fields { args { name defaultValue } } } }`,
+	})
+
+	if len(result.Errors) != 0 {
+		t.Fatal(result.Errors)
+	}
+
+	// arguments are introspected in a random order
+	defaultValues := map[string]interface{}{}
+	fields := result.Data.(map[string]interface{})["__type"].(map[string]interface{})["fields"].([]interface{})
+	for _, arg := range fields[0].(map[string]interface{})["args"].([]interface{}) {
+		arg := arg.(map[string]interface{})
+		defaultValues[arg["name"].(string)] = arg["defaultValue"]
+	}
+
+	expected := map[string]interface{}{
+		"first":  "10",
+		"status": "BANNED",
+		"filter": "{limit: 3}",
+	}
+
+	if !reflect.DeepEqual(defaultValues, expected) {
+		t.Fatalf("expected %v, got %v", expected, defaultValues)
+	}
+}
+
+func TestDefaultValueSDL(t *testing.T) {
+	sdl, err := groot.PrintSchema(groot.SchemaConfig{
+		Query: groot.MustParseObject(NonNullDefaultValueQuery{}),
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `schema {
+  query: NonNullDefaultValueQuery
+}
+
+input DefaultValueFilter {
+  limit: Int!
+}
+
+enum DefaultValueStatus {
+  ACTIVE
+  BANNED
+}
+
+type NonNullDefaultValueQuery {
+  items(filter: DefaultValueFilter = {limit: 3}, first: Int = 10, status: DefaultValueStatus = BANNED): String!
+}
+`
+
+	if sdl != expected {
+		t.Fatalf("expected %s, got %s", expected, sdl)
+	}
+}
@@ -1,29 +1,34 @@
 package groot
 
 import (
-	"reflect"
-
 	"github.com/graphql-go/graphql"
 	"github.com/shreyas44/groot/parser"
 )
 
-type EnumType = parser.EnumType
+type (
+	EnumType           = parser.EnumType
+	EnumTypeWithValues = parser.EnumTypeWithValues
+	EnumValue          = parser.EnumValue
+)
 
 func NewEnum(t *parser.Enum, builder *SchemaBuilder) *graphql.Enum {
-	name := t.ReflectType().Name()
-	enumType := reflect.New(t.ReflectType()).Interface().(EnumType)
-
 	values := graphql.EnumValueConfigMap{}
-	for _, value := range enumType.Values() {
-		values[value] = &graphql.EnumValueConfig{
-			Value: value,
+	for _, value := range t.Values() {
+		if !builder.isVisible(value.Visibility) {
+			continue
+		}
+
+		values[builder.enumValueName(value)] = &graphql.EnumValueConfig{
+			Value:             value.Value,
+			Description:       value.Description,
+			DeprecationReason: value.Deprecated,
 		}
 	}
 
-	// TODO: enum description, value descriptions, value deprecation
 	enum := graphql.NewEnum(graphql.EnumConfig{
-		Name:   name,
-		Values: values,
+		Name:        t.Name(),
+		Description: t.Description(),
+		Values:      values,
 	})
 
 	builder.addType(t, enum)
@@ -0,0 +1,209 @@
+package groot
+
+import (
+	"context"
+	"errors"
+	"log"
+	"os"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+)
+
+const (
+	// CodeInternalServerError is the code of errors masked by the presenter
+	// returned by NewMaskingErrorPresenter
+	CodeInternalServerError = "INTERNAL_SERVER_ERROR"
+	// CodeBadUserInput is the code of errors returned by the Validate methods
+	// of arguments
+	CodeBadUserInput = "BAD_USER_INPUT"
+)
+
+// Error is an error sent to clients with a code and extensions. The message
+// of the error is public, while the internal error it wraps is never sent to
+// clients, and can be logged instead.
+type Error struct {
+	// Code is added to the extensions of the error as "code"
+	Code string
+	// Message is the message sent to clients
+	Message string
+	// Details are added to the extensions of the error along with the code
+	Details map[string]interface{}
+	// Internal is the error that caused the error
+	Internal error
+}
+
+func NewError(code, message string) *Error {
+	return &Error{Code: code, Message: message}
+}
+
+// WrapError returns an error sent to clients with the given code and message
+// instead of the message of err
+func WrapError(err error, code, message string) *Error {
+	return &Error{Code: code, Message: message, Internal: err}
+}
+
+func (err *Error) Error() string {
+	return err.Message
+}
+
+func (err *Error) Unwrap() error {
+	return err.Internal
+}
+
+// InternalMessage returns the message of the internal error, falling back to
+// the public message if there's no internal error
+func (err *Error) InternalMessage() string {
+	if err.Internal == nil {
+		return err.Message
+	}
+
+	return err.Internal.Error()
+}
+
+// Extensions returns the extensions of the error sent to clients, which are
+// the details of the error and its code
+func (err *Error) Extensions() map[string]interface{} {
+	if err.Code == "" && len(err.Details) == 0 {
+		return nil
+	}
+
+	extensions := map[string]interface{}{}
+	for key, value := range err.Details {
+		extensions[key] = value
+	}
+
+	if err.Code != "" {
+		extensions["code"] = err.Code
+	}
+
+	return extensions
+}
+
+// newInputError returns the error sent to clients for an error returned by
+// the Validate method of arguments, which is meant for clients
+func newInputError(err error) error {
+	var grootErr *Error
+	if errors.As(err, &grootErr) {
+		return err
+	}
+
+	return WrapError(err, CodeBadUserInput, err.Error())
+}
+
+// ErrorPresenter converts an error returned while resolving the field at path
+// to the error sent to clients. It can map domain errors to codes, and mask
+// and log unexpected errors. If it returns nil, err is sent as it is.
+type ErrorPresenter func(ctx context.Context, path []interface{}, err error) *Error
+
+// DefaultErrorPresenter is the presenter of schemas without an ErrorPresenter.
+// Errors created with NewError or WrapError are sent with their public message
+// even if they're wrapped, and any other error is sent as it is.
+func DefaultErrorPresenter(ctx context.Context, path []interface{}, err error) *Error {
+	var grootErr *Error
+	if errors.As(err, &grootErr) {
+		return grootErr
+	}
+
+	return nil
+}
+
+// Logger logs the errors masked by the presenter returned by
+// NewMaskingErrorPresenter. *log.Logger implements it.
+type Logger interface {
+	Printf(format string, v ...interface{})
+}
+
+// NewMaskingErrorPresenter returns a presenter which sends errors created with
+// NewError or WrapError, and errors with their own extensions like
+// TimeoutError, as they are. Any other error may contain internal details, so
+// it's logged with logger and sent as an internal error. Errors are logged to
+// standard error if logger is nil.
+func NewMaskingErrorPresenter(logger Logger) ErrorPresenter {
+	if logger == nil {
+		logger = log.New(os.Stderr, "", log.LstdFlags)
+	}
+
+	return func(ctx context.Context, path []interface{}, err error) *Error {
+		var grootErr *Error
+		if errors.As(err, &grootErr) {
+			return grootErr
+		}
+
+		var extendedErr gqlerrors.ExtendedError
+		if errors.As(err, &extendedErr) {
+			return nil
+		}
+
+		logger.Printf("groot: error resolving %v: %v", path, err)
+		return WrapError(err, CodeInternalServerError, "internal server error")
+	}
+}
+
+type errorPresenterKey struct{}
+
+// PathError is an error of a value inside the value a resolver returns, like
+// an item of a list that failed to load. The field still resolves to the
+// value the resolver returns, and the error is added to the response with the
+// path of the field followed by Path.
+type PathError struct {
+	Path []interface{}
+	Err  error
+}
+
+// ErrorAt returns an error of the value at path inside the value a resolver
+// returns, where the path is made of list indexes and field names
+func ErrorAt(err error, path ...interface{}) *PathError {
+	return &PathError{Path: path, Err: err}
+}
+
+func (err *PathError) Error() string {
+	return err.Err.Error()
+}
+
+func (err *PathError) Unwrap() error {
+	return err.Err
+}
+
+// presentError converts an error of a field with the presenter of the schema
+func presentError(p graphql.ResolveParams, err error) error {
+	return presentErrorAt(p.Context, p.Info.Path.AsArray(), err)
+}
+
+func presentErrorAt(ctx context.Context, path []interface{}, err error) error {
+	if err == nil || ctx == nil {
+		return err
+	}
+
+	present, _ := ctx.Value(errorPresenterKey{}).(ErrorPresenter)
+	if present == nil {
+		return err
+	}
+
+	if presented := present(ctx, path, err); presented != nil {
+		return presented
+	}
+
+	return err
+}
+
+// newPresentingFieldResolver converts the errors returned by a resolver, and
+// any thunk it returns, with the presenter of the schema
+func newPresentingFieldResolver(resolve fieldResolver) fieldResolver {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		value, err := resolve(p)
+		if err != nil {
+			return value, presentError(p, err)
+		}
+
+		thunk, isThunk := value.(func() (interface{}, error))
+		if !isThunk {
+			return value, nil
+		}
+
+		return func() (interface{}, error) {
+			value, err := thunk()
+			return value, presentError(p, err)
+		}, nil
+	}
+}
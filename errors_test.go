@@ -0,0 +1,110 @@
+package groot_test
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/shreyas44/groot"
+)
+
+type ErrorsQuery struct {
+	Internal string  `json:"internal"`
+	Public   string  `json:"public"`
+	Search   *string `json:"search"`
+}
+
+type ErrorsSearchArgs struct {
+	Text string `json:"text"`
+}
+
+func (args ErrorsSearchArgs) Validate() error {
+	if args.Text == "" {
+		return errors.New("text cannot be empty")
+	}
+
+	return nil
+}
+
+func (query ErrorsQuery) ResolveInternal() (string, error) {
+	return "", errors.New("pq: connection refused")
+}
+
+func (query ErrorsQuery) ResolvePublic() (string, error) {
+	return "", groot.WrapError(errors.New("no rows"), "NOT_FOUND", "not found")
+}
+
+func (query ErrorsQuery) ResolveSearch(args ErrorsSearchArgs) (*string, error) {
+	return &args.Text, nil
+}
+
+func assertErrors(t *testing.T, schema graphql.Schema, tests []errorTest) {
+	t.Helper()
+
+	for _, test := range tests {
+		result := graphql.Do(graphql.Params{Schema: schema, RequestString: test.query})
+		if len(result.Errors) != 1 {
+			t.Fatalf("%s: expected one error, got %v", test.query, result.Errors)
+		}
+
+		err := result.Errors[0]
+		if err.Message != test.message || err.Extensions["code"] != test.code {
+			t.Errorf("%s: expected %q with code %s, got %q with extensions %v", test.query, test.message, test.code, err.Message, err.Extensions)
+		}
+	}
+}
+
+type errorTest struct {
+	query   string
+	message string
+	code    interface{}
+}
+
+func TestDefaultErrorPresenter(t *testing.T) {
+	schema, err := groot.NewSchema(groot.SchemaConfig{
+		Query: groot.MustParseObject(ErrorsQuery{}),
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertErrors(t, schema, []errorTest{
+		{`{ internal }`, "pq: connection refused", nil},
+		{`{ public }`, "not found", "NOT_FOUND"},
+		{`{ search(text: "") }`, "text cannot be empty", groot.CodeBadUserInput},
+	})
+}
+
+type testLogger struct {
+	messages []string
+}
+
+func (logger *testLogger) Printf(format string, v ...interface{}) {
+	logger.messages = append(logger.messages, fmt.Sprintf(format, v...))
+}
+
+func TestMaskingErrorPresenter(t *testing.T) {
+	logger := &testLogger{}
+	schema, err := groot.NewSchema(groot.SchemaConfig{
+		Query:          groot.MustParseObject(ErrorsQuery{}),
+		ErrorPresenter: groot.NewMaskingErrorPresenter(logger),
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertErrors(t, schema, []errorTest{
+		{`{ internal }`, "internal server error", groot.CodeInternalServerError},
+		{`{ public }`, "not found", "NOT_FOUND"},
+		{`{ search(text: "") }`, "text cannot be empty", groot.CodeBadUserInput},
+	})
+
+	expected := []string{"groot: error resolving [internal]: pq: connection refused"}
+	if !reflect.DeepEqual(logger.messages, expected) {
+		t.Fatalf("expected %v to be logged, got %v", expected, logger.messages)
+	}
+}
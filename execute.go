@@ -0,0 +1,251 @@
+package groot
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+	"github.com/shreyas44/groot/internal/lru"
+)
+
+// DefaultDocumentCacheSize is the number of documents kept by an Executor if
+// ExecutorConfig.DocumentCacheSize isn't set
+const DefaultDocumentCacheSize = 1000
+
+// ExecutorConfig configures what an Executor does on top of executing the
+// schema, which graphql.Do doesn't support
+type ExecutorConfig struct {
+	// MaxDepth and MaxCost reject operations nested deeper than MaxDepth
+	// fields, or with a cost above MaxCost, before they are executed. The
+	// cost of fields is set with the cost tag or a Cost<Field> method, and is
+	// multiplied by arguments with the multiplier tag.
+	MaxDepth int
+	MaxCost  int
+
+	// DocumentCacheSize is the number of parsed and validated documents kept
+	// by the executor, DefaultDocumentCacheSize by default
+	DocumentCacheSize int
+}
+
+// Request is a GraphQL request to execute with Executor.Execute
+type Request struct {
+	Query         string
+	OperationName string
+	Variables     map[string]interface{}
+	Root          map[string]interface{}
+	Context       context.Context
+}
+
+// Executor executes requests like graphql.Do, but parses and validates each
+// query only once, caching the document by the hash of the query
+type Executor struct {
+	// accessed atomically, so they're first to be 64-bit aligned
+	hits   uint64
+	misses uint64
+
+	schema     graphql.Schema
+	extensions []graphql.Extension
+	cache      *lru.Cache
+	// limits are the complexity limits of the schema, nil if there are none
+	limits *complexityLimits
+}
+
+// DocumentCacheStats are the statistics of the document cache of an executor
+// since it was created
+type DocumentCacheStats struct {
+	Hits   uint64
+	Misses uint64
+	// Size is the number of documents in the cache
+	Size int
+}
+
+// HitRate returns the share of requests which were found in the cache,
+// between 0 and 1
+func (stats DocumentCacheStats) HitRate() float64 {
+	total := stats.Hits + stats.Misses
+	if total == 0 {
+		return 0
+	}
+
+	return float64(stats.Hits) / float64(total)
+}
+
+type cachedDocument struct {
+	document *ast.Document
+	// parseErr and validationErrors are the errors of parsing and validating
+	// the document
+	parseErr         error
+	validationErrors []gqlerrors.FormattedError
+}
+
+// NewExecutor builds the schema of a config, and returns an executor for it
+func NewExecutor(config SchemaConfig, executorConfig ExecutorConfig) (*Executor, error) {
+	schema, builder, err := buildSchema(config)
+	if err != nil {
+		return nil, err
+	}
+
+	cacheSize := executorConfig.DocumentCacheSize
+	if cacheSize <= 0 {
+		cacheSize = DefaultDocumentCacheSize
+	}
+
+	return &Executor{
+		schema:     schema,
+		extensions: builder.extensions,
+		cache:      lru.New(cacheSize),
+		limits:     builder.newComplexityLimits(executorConfig.MaxDepth, executorConfig.MaxCost),
+	}, nil
+}
+
+// Schema returns the schema requests are executed with
+func (e *Executor) Schema() graphql.Schema {
+	return e.schema
+}
+
+func (e *Executor) Stats() DocumentCacheStats {
+	return DocumentCacheStats{
+		Hits:   atomic.LoadUint64(&e.hits),
+		Misses: atomic.LoadUint64(&e.misses),
+		Size:   e.cache.Len(),
+	}
+}
+
+// Execute executes a request, using the cache to skip parsing and validating
+// the query if it was executed before. Extensions are called the same way
+// graphql.Do calls them, including the parse and validation hooks of cached
+// queries.
+func (e *Executor) Execute(request Request) *graphql.Result {
+	params := graphql.Params{
+		Schema:         e.schema,
+		RequestString:  request.Query,
+		RootObject:     request.Root,
+		VariableValues: request.Variables,
+		OperationName:  request.OperationName,
+		Context:        request.Context,
+	}
+
+	errs := runExtensions(e.extensions, "Init", func(ext graphql.Extension) {
+		params.Context = ext.Init(params.Context, &params)
+	})
+
+	if len(errs) != 0 {
+		return &graphql.Result{Errors: errs}
+	}
+
+	var parseFinishFuncs []graphql.ParseFinishFunc
+	errs = runExtensions(e.extensions, "ParseDidStart", func(ext graphql.Extension) {
+		var finish graphql.ParseFinishFunc
+		params.Context, finish = ext.ParseDidStart(params.Context)
+		parseFinishFuncs = append(parseFinishFuncs, finish)
+	})
+
+	if len(errs) != 0 {
+		return &graphql.Result{Errors: errs}
+	}
+
+	key := sha256.Sum256([]byte(request.Query))
+	document, isCached := e.getDocument(key)
+	if !isCached {
+		document = &cachedDocument{}
+		document.document, document.parseErr = parseDocument(request.Query)
+	}
+
+	for _, finish := range parseFinishFuncs {
+		finish(document.parseErr)
+	}
+
+	if document.parseErr != nil {
+		if !isCached {
+			e.cache.Set(key, document)
+		}
+
+		return &graphql.Result{Errors: gqlerrors.FormatErrors(document.parseErr)}
+	}
+
+	var validationFinishFuncs []graphql.ValidationFinishFunc
+	errs = runExtensions(e.extensions, "ValidationDidStart", func(ext graphql.Extension) {
+		var finish graphql.ValidationFinishFunc
+		params.Context, finish = ext.ValidationDidStart(params.Context)
+		validationFinishFuncs = append(validationFinishFuncs, finish)
+	})
+
+	if len(errs) != 0 {
+		return &graphql.Result{Errors: errs}
+	}
+
+	if !isCached {
+		document.validationErrors = graphql.ValidateDocument(&e.schema, document.document, nil).Errors
+		e.cache.Set(key, document)
+	}
+
+	// copy the errors since they're shared by every request with the query
+	validationErrors := append([]gqlerrors.FormattedError{}, document.validationErrors...)
+	if len(validationErrors) == 0 && e.limits != nil {
+		validationErrors = e.limits.check(&e.schema, document.document, params.OperationName, params.VariableValues)
+	}
+
+	for _, finish := range validationFinishFuncs {
+		finish(validationErrors)
+	}
+
+	if len(validationErrors) != 0 {
+		return &graphql.Result{Errors: validationErrors}
+	}
+
+	return graphql.Execute(graphql.ExecuteParams{
+		Schema:        e.schema,
+		Root:          params.RootObject,
+		AST:           document.document,
+		OperationName: params.OperationName,
+		Args:          params.VariableValues,
+		Context:       params.Context,
+	})
+}
+
+// getDocument returns the cached document of a query, counting the hit or
+// miss
+func (e *Executor) getDocument(key [sha256.Size]byte) (*cachedDocument, bool) {
+	value, ok := e.cache.Get(key)
+	if !ok {
+		atomic.AddUint64(&e.misses, 1)
+		return nil, false
+	}
+
+	atomic.AddUint64(&e.hits, 1)
+	return value.(*cachedDocument), true
+}
+
+func parseDocument(query string) (*ast.Document, error) {
+	return parser.Parse(parser.ParseParams{
+		Source: source.NewSource(&source.Source{
+			Body: []byte(query),
+			Name: "GraphQL request",
+		}),
+	})
+}
+
+// runExtensions calls a hook of each extension, converting panics to errors
+// like graphql.Do
+func runExtensions(extensions []graphql.Extension, hook string, fn func(ext graphql.Extension)) []gqlerrors.FormattedError {
+	errs := []gqlerrors.FormattedError{}
+	for _, ext := range extensions {
+		func() {
+			defer func() {
+				if r := recover(); r != nil {
+					errs = append(errs, gqlerrors.FormatError(fmt.Errorf("%s.%s: %v", ext.Name(), hook, r)))
+				}
+			}()
+
+			fn(ext)
+		}()
+	}
+
+	return errs
+}
@@ -0,0 +1,90 @@
+package groot_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/shreyas44/groot"
+)
+
+type ExecuteQuery struct {
+	Hello string `json:"hello"`
+}
+
+func (query ExecuteQuery) ResolveHello() (string, error) {
+	return "world", nil
+}
+
+// countingExtension counts the calls of the parse and validation hooks
+type countingExtension struct {
+	parses      int64
+	validations int64
+	errors      int64
+}
+
+func (ext *countingExtension) Init(ctx context.Context, p *graphql.Params) context.Context {
+	return ctx
+}
+
+func (ext *countingExtension) Name() string {
+	return "counting"
+}
+
+func (ext *countingExtension) ParseDidStart(ctx context.Context) (context.Context, graphql.ParseFinishFunc) {
+	atomic.AddInt64(&ext.parses, 1)
+	return ctx, func(err error) {}
+}
+
+func (ext *countingExtension) ValidationDidStart(ctx context.Context) (context.Context, graphql.ValidationFinishFunc) {
+	atomic.AddInt64(&ext.validations, 1)
+	return ctx, func(errs []gqlerrors.FormattedError) {
+		atomic.AddInt64(&ext.errors, int64(len(errs)))
+	}
+}
+
+func (ext *countingExtension) ExecutionDidStart(ctx context.Context) (context.Context, graphql.ExecutionFinishFunc) {
+	return ctx, func(result *graphql.Result) {}
+}
+
+func (ext *countingExtension) ResolveFieldDidStart(ctx context.Context, info *graphql.ResolveInfo) (context.Context, graphql.ResolveFieldFinishFunc) {
+	return ctx, func(v interface{}, err error) {}
+}
+
+func (ext *countingExtension) HasResult() bool {
+	return false
+}
+
+func (ext *countingExtension) GetResult(ctx context.Context) interface{} {
+	return nil
+}
+
+func TestExecutorCallsHooksOfCachedQueries(t *testing.T) {
+	ext := &countingExtension{}
+	executor, err := groot.NewExecutor(groot.SchemaConfig{
+		Query:      groot.MustParseObject(ExecuteQuery{}),
+		Extensions: []graphql.Extension{ext},
+	}, groot.ExecutorConfig{})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 3; i++ {
+		assertResult(t, executor.Execute(groot.Request{Query: "{ hello }"}), `{"hello":"world"}`)
+		if result := executor.Execute(groot.Request{Query: "{ goodbye }"}); len(result.Errors) != 1 {
+			t.Fatalf("expected a validation error, got %v", result.Errors)
+		}
+	}
+
+	if ext.parses != 6 || ext.validations != 6 || ext.errors != 3 {
+		t.Fatalf("expected 6 parses, 6 validations and 3 errors, got %d, %d and %d", ext.parses, ext.validations, ext.errors)
+	}
+
+	stats := executor.Stats()
+	if stats.Hits != 4 || stats.Misses != 2 || stats.Size != 2 {
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+}
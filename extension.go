@@ -0,0 +1,263 @@
+package groot
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+)
+
+type resultCollectorKey struct{}
+
+// resultCollector collects what groot adds to the result of a request after
+// graphql-go executes it
+type resultCollector struct {
+	mu sync.Mutex
+	// errors are the errors of fields that resolve to a value despite failing,
+	// which graphql-go doesn't support on its own
+	errors        []gqlerrors.FormattedError
+	introspection introspection
+	// finished is set once the result is complete, after which errors of
+	// abandoned resolvers are dropped
+	finished bool
+}
+
+func resultCollectorFromContext(ctx context.Context) *resultCollector {
+	if ctx == nil {
+		return nil
+	}
+
+	collector, _ := ctx.Value(resultCollectorKey{}).(*resultCollector)
+	return collector
+}
+
+// finish adds the errors to the result, sets the extensions of errors
+// graphql-go dropped, and fixes the introspection fields of the result. It's
+// called by the finish func of every extension, so the result is complete
+// whichever runs first.
+func (collector *resultCollector) finish(result *graphql.Result) {
+	collector.mu.Lock()
+	defer collector.mu.Unlock()
+
+	if collector.finished {
+		return
+	}
+
+	collector.finished = true
+	result.Errors = append(result.Errors, collector.errors...)
+	for i, err := range result.Errors {
+		if err.Extensions == nil {
+			result.Errors[i].Extensions = getErrorExtensions(err)
+		}
+	}
+
+	collector.introspection.fix(result.Data)
+}
+
+// addFieldErrors adds errors of a field which still resolves to a value to the
+// response. It returns the first error if the errors cannot be added, in which
+// case the field resolves to null.
+func addFieldErrors(p graphql.ResolveParams, errs []error) error {
+	if len(errs) == 0 {
+		return nil
+	}
+
+	collector := resultCollectorFromContext(p.Context)
+	if collector == nil {
+		return errs[0]
+	}
+
+	if buffer, ok := p.Context.Value(fieldErrorBufferKey{}).(*fieldErrorBuffer); ok {
+		buffer.add(p, errs)
+		return nil
+	}
+
+	collector.addFieldErrors(p, errs)
+	return nil
+}
+
+func (collector *resultCollector) addFieldErrors(p graphql.ResolveParams, errs []error) {
+	nodes := graphql.FieldASTsToNodeASTs(p.Info.FieldASTs)
+	path := p.Info.Path.AsArray()
+
+	collector.mu.Lock()
+	defer collector.mu.Unlock()
+
+	// the response was already built without the field
+	if collector.finished {
+		return
+	}
+
+	for _, err := range errs {
+		errPath := path
+		var pathErr *PathError
+		if errors.As(err, &pathErr) {
+			errPath = append(append([]interface{}{}, path...), pathErr.Path...)
+			err = pathErr.Err
+		}
+
+		err = presentErrorAt(p.Context, errPath, err)
+		located := graphql.NewLocatedErrorWithPath(err, nodes, errPath)
+		collector.errors = append(collector.errors, gqlerrors.FormatError(located))
+	}
+}
+
+type fieldErrorBufferKey struct{}
+
+// fieldErrorBuffer holds the errors added by the resolver of a field with a
+// timeout until the field resolves, so the errors of a resolver abandoned
+// after the timeout are never added to the response
+type fieldErrorBuffer struct {
+	mu      sync.Mutex
+	pending []fieldErrors
+	flushed bool
+}
+
+type fieldErrors struct {
+	p    graphql.ResolveParams
+	errs []error
+}
+
+func (buffer *fieldErrorBuffer) add(p graphql.ResolveParams, errs []error) {
+	buffer.mu.Lock()
+	defer buffer.mu.Unlock()
+
+	if !buffer.flushed {
+		buffer.pending = append(buffer.pending, fieldErrors{p, errs})
+	}
+}
+
+// flush adds the errors to the response if the field resolved to a value,
+// which is when err is nil, and drops them otherwise. Errors added later are
+// dropped either way. It returns err.
+func (buffer *fieldErrorBuffer) flush(p graphql.ResolveParams, err error) error {
+	buffer.mu.Lock()
+	pending := buffer.pending
+	buffer.pending = nil
+	buffer.flushed = true
+	buffer.mu.Unlock()
+
+	collector := resultCollectorFromContext(p.Context)
+	if err != nil || collector == nil {
+		return err
+	}
+
+	for _, fieldErrors := range pending {
+		collector.addFieldErrors(fieldErrors.p, fieldErrors.errs)
+	}
+
+	return nil
+}
+
+// getErrorExtensions returns the extensions of the error a formatted error was
+// created from. graphql-go drops them for errors returned by thunks, since the
+// error is formatted twice.
+func getErrorExtensions(err error) map[string]interface{} {
+	for err != nil {
+		switch e := err.(type) {
+		case gqlerrors.ExtendedError:
+			return e.Extensions()
+		case gqlerrors.FormattedError:
+			err = e.OriginalError()
+		case *gqlerrors.Error:
+			err = e.OriginalError
+		default:
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// extension is added to every schema to support features graphql-go doesn't
+// support on its own
+type extension struct {
+	maxConcurrency int
+	errorPresenter ErrorPresenter
+	// fieldRules are the rules of fields hidden from introspection queries
+	// of requests they deny, set if SchemaConfig.HideUnauthorizedFields is
+	fieldRules map[*graphql.FieldDefinition]FieldRule
+}
+
+func newExtension(config SchemaConfig) *extension {
+	ext := &extension{
+		maxConcurrency: config.MaxConcurrency,
+		errorPresenter: config.ErrorPresenter,
+	}
+
+	if ext.errorPresenter == nil {
+		ext.errorPresenter = DefaultErrorPresenter
+	}
+
+	return ext
+}
+
+func (ext *extension) Init(ctx context.Context, p *graphql.Params) context.Context {
+	return ctx
+}
+
+func (ext *extension) Name() string {
+	return "groot"
+}
+
+func (ext *extension) ParseDidStart(ctx context.Context) (context.Context, graphql.ParseFinishFunc) {
+	return ctx, func(err error) {}
+}
+
+func (ext *extension) ValidationDidStart(ctx context.Context) (context.Context, graphql.ValidationFinishFunc) {
+	return ctx, func(errs []gqlerrors.FormattedError) {}
+}
+
+func (ext *extension) ExecutionDidStart(ctx context.Context) (context.Context, graphql.ExecutionFinishFunc) {
+	// graphql.Params.Context is optional
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	collector := &resultCollector{
+		introspection: introspection{ctx: ctx, fieldRules: ext.fieldRules},
+	}
+	ctx = context.WithValue(ctx, resultCollectorKey{}, collector)
+	ctx = context.WithValue(ctx, workerPoolKey{}, newWorkerPool(ext.maxConcurrency))
+	ctx = context.WithValue(ctx, errorPresenterKey{}, ext.errorPresenter)
+
+	return ctx, collector.finish
+}
+
+// orderedExtension wraps the other extensions of a schema, to complete the
+// result before their execution finish funcs are called, which graphql-go
+// calls in a random order
+type orderedExtension struct {
+	graphql.Extension
+}
+
+func (ext orderedExtension) ExecutionDidStart(ctx context.Context) (context.Context, graphql.ExecutionFinishFunc) {
+	collector := resultCollectorFromContext(ctx)
+	ctx, finish := ext.Extension.ExecutionDidStart(ctx)
+	return ctx, func(result *graphql.Result) {
+		if collector != nil {
+			collector.finish(result)
+		}
+
+		finish(result)
+	}
+}
+
+func (ext *extension) ResolveFieldDidStart(ctx context.Context, info *graphql.ResolveInfo) (context.Context, graphql.ResolveFieldFinishFunc) {
+	collector := resultCollectorFromContext(ctx)
+	if collector == nil || info == nil {
+		return ctx, func(v interface{}, err error) {}
+	}
+
+	return ctx, collector.resolveIntrospectionField(info)
+}
+
+func (ext *extension) HasResult() bool {
+	return false
+}
+
+func (ext *extension) GetResult(ctx context.Context) interface{} {
+	return nil
+}
@@ -8,21 +8,33 @@ import (
 func NewField(parserField *parser.Field, builder *SchemaBuilder) *graphql.Field {
 	var subscribe fieldSubscriber
 	graphqlType := getOrCreateType(parserField.Type(), builder)
+	if builder.omitEmptyNullable && parserField.OmitEmpty() {
+		graphqlType = GetNullable(graphqlType).(graphql.Type)
+	}
 
 	if parserField.Subscriber() != nil {
-		subscribe = newFieldSubscriber(parserField.Subscriber(), parserField.Type())
+		subscribe = newFieldSubscriber(parserField.Subscriber(), parserField.Type(), builder)
+	}
+
+	argsInput := parserField.ArgsInput()
+	if resolver := builder.getResolver(parserField); resolver != nil {
+		argsInput = resolver.ArgsInput()
 	}
 
 	args := graphql.FieldConfigArgument{}
-	for _, parserArgs := range parserField.ArgsInput().Arguments() {
-		args[parserArgs.JSONName()] = NewArgument(parserArgs, builder)
+	for _, parserArgs := range argsInput.Arguments() {
+		if !builder.isArgumentVisible(parserArgs) {
+			continue
+		}
+
+		args[builder.argumentName(parserArgs)] = NewArgument(parserArgs, builder)
 	}
 
 	field := &graphql.Field{
-		Name:              parserField.JSONName(),
+		Name:              builder.fieldName(parserField),
 		Type:              graphqlType,
 		Description:       parserField.Description(),
-		Resolve:           newFieldResolver(parserField),
+		Resolve:           newFieldResolver(parserField, builder),
 		DeprecationReason: parserField.DeprecationReason(),
 		Args:              args,
 		Subscribe:         subscribe,
@@ -2,4 +2,10 @@ module github.com/shreyas44/groot
 
 go 1.15
 
-require github.com/graphql-go/graphql v0.8.0
+require (
+	github.com/graphql-go/graphql v0.8.0
+	github.com/prometheus/client_golang v1.11.0
+	go.opentelemetry.io/otel v1.0.1
+	go.opentelemetry.io/otel/sdk v1.0.1
+	go.opentelemetry.io/otel/trace v1.0.1
+)
@@ -6,24 +6,26 @@ import (
 )
 
 func NewInputObject(input *parser.Input, builder *SchemaBuilder) *graphql.InputObject {
-	// TODO: description
 	object := graphql.NewInputObject(graphql.InputObjectConfig{
-		Name:   input.ReflectType().Name(),
-		Fields: graphql.InputObjectConfigFieldMap{},
+		Name:        input.Name(),
+		Description: input.Description(),
+		Fields:      graphql.InputObjectConfigFieldMap{},
 	})
 
 	builder.addType(input, object)
 	for _, arg := range input.Arguments() {
-		config := &graphql.InputObjectFieldConfig{
-			Type:        NewArgument(arg, builder).Type,
-			Description: arg.Description(),
+		if !builder.isArgumentVisible(arg) {
+			continue
 		}
 
-		if arg.DefaultValue() != "" {
-			config.DefaultValue = arg.DefaultValue()
+		argument := NewArgument(arg, builder)
+		config := &graphql.InputObjectFieldConfig{
+			Type:         argument.Type,
+			Description:  arg.Description(),
+			DefaultValue: argument.DefaultValue,
 		}
 
-		object.AddFieldConfig(arg.JSONName(), config)
+		object.AddFieldConfig(builder.argumentName(arg), config)
 	}
 
 	return object
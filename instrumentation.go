@@ -0,0 +1,95 @@
+package groot
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/shreyas44/groot/parser"
+)
+
+// FieldExtension is implemented by extensions which instrument the resolvers
+// of fields. graphql-go replaces the context of the whole request with the
+// context returned by ResolveFieldDidStart, and finishes it before thunks
+// returned by resolvers are called, so FieldDidStart is called around the Go
+// resolver instead, and the context it returns is only passed to the resolver.
+type FieldExtension interface {
+	graphql.Extension
+	FieldDidStart(ctx context.Context, field FieldInfo) (context.Context, FieldFinishFunc)
+}
+
+// FieldFinishFunc is called with the result of a resolver, after the thunk it
+// returns, if any, is called, or with the error of a resolver which timed out
+type FieldFinishFunc func(result interface{}, err error)
+
+// FieldInfo describes a field being resolved
+type FieldInfo struct {
+	Info  graphql.ResolveInfo
+	Field *parser.Field
+	// Resolver is the method resolving the field, or nil if the field is
+	// resolved from the struct field by the default resolver or a transformer
+	Resolver *parser.Resolver
+}
+
+// IsDefaultResolved reports whether the field is resolved from the struct
+// field without any method
+func (info FieldInfo) IsDefaultResolved() bool {
+	return info.Resolver == nil && info.Field.Transformer() == nil
+}
+
+// OperationName returns the name of the operation a field is resolved in, which
+// is taken from the document, so it's set even if the request doesn't have an
+// operation name
+func OperationName(info graphql.ResolveInfo) string {
+	operation, ok := info.Operation.(*ast.OperationDefinition)
+	if !ok || operation.Name == nil {
+		return ""
+	}
+
+	return operation.Name.Value
+}
+
+// instrumentFieldResolver calls the FieldExtensions of the schema around a
+// resolver
+func (builder *SchemaBuilder) instrumentFieldResolver(field *parser.Field, resolver *parser.Resolver, resolve fieldResolver) fieldResolver {
+	extensions := builder.fieldExtensions
+	if len(extensions) == 0 {
+		return resolve
+	}
+
+	return func(p graphql.ResolveParams) (value interface{}, err error) {
+		ctx := requestContext(p)
+		info := FieldInfo{p.Info, field, resolver}
+		finishFuncs := make([]FieldFinishFunc, len(extensions))
+		for i, ext := range extensions {
+			ctx, finishFuncs[i] = ext.FieldDidStart(ctx, info)
+		}
+
+		finish := func(value interface{}, err error) {
+			for _, finishFunc := range finishFuncs {
+				finishFunc(value, err)
+			}
+		}
+
+		defer func() {
+			if r := recover(); r != nil {
+				finish(nil, fmt.Errorf("%v", r))
+				panic(r)
+			}
+		}()
+
+		p.Context = ctx
+		value, err = resolve(p)
+		if thunk, ok := value.(func() (interface{}, error)); ok && err == nil {
+			return func() (interface{}, error) {
+				value, err := thunk()
+				finish(value, err)
+				return value, err
+			}, nil
+		}
+
+		finish(value, err)
+		return value, err
+	}
+}
@@ -10,19 +10,32 @@ import (
 type InterfaceType = parser.InterfaceType
 
 func NewInterface(parserInterface *parser.Interface, builder *SchemaBuilder) *graphql.Interface {
-	// TODO: description
 	interface_ := graphql.NewInterface(graphql.InterfaceConfig{
-		Name:   parserInterface.Name(),
-		Fields: graphql.Fields{},
+		Name:        parserInterface.Name(),
+		Description: parserInterface.Description(),
+		Fields:      graphql.Fields{},
 		ResolveType: func(p graphql.ResolveTypeParams) *graphql.Object {
 			valueType := reflect.TypeOf(p.Value)
-			return builder.reflectGrootMap[valueType].(*graphql.Object)
+			if valueType.Kind() == reflect.Ptr {
+				valueType = valueType.Elem()
+			}
+
+			object, _ := builder.reflectGrootMap[valueType].(*graphql.Object)
+			return object
 		},
 	})
 
 	builder.addType(parserInterface, interface_)
-	for _, field := range parserInterface.Fields() {
-		interface_.AddFieldConfig(field.JSONName(), NewField(field, builder))
+	for _, parserField := range parserInterface.Fields() {
+		if !builder.isFieldVisible(parserField) {
+			continue
+		}
+
+		field := NewField(parserField, builder)
+		builder.hideValues(parserField, field)
+		builder.authorizeField(interface_, parserField, field)
+		builder.addFieldCost(interface_, parserField, field)
+		interface_.AddFieldConfig(field.Name, field)
 	}
 
 	return interface_
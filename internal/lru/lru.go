@@ -0,0 +1,72 @@
+// Package lru implements a fixed size cache which evicts the least recently
+// used entries first
+package lru
+
+import (
+	"container/list"
+	"sync"
+)
+
+// Cache is a least recently used cache, safe for concurrent use
+type Cache struct {
+	mu      sync.Mutex
+	size    int
+	entries map[interface{}]*list.Element
+	order   *list.List
+}
+
+type entry struct {
+	key   interface{}
+	value interface{}
+}
+
+// New returns a cache holding up to size entries
+func New(size int) *Cache {
+	if size <= 0 {
+		panic("lru: size must be positive")
+	}
+
+	return &Cache{
+		size:    size,
+		entries: map[interface{}]*list.Element{},
+		order:   list.New(),
+	}
+}
+
+func (c *Cache) Get(key interface{}) (interface{}, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	c.order.MoveToFront(element)
+	return element.Value.(*entry).value, true
+}
+
+func (c *Cache) Set(key interface{}, value interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.entries[key]; ok {
+		element.Value.(*entry).value = value
+		c.order.MoveToFront(element)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&entry{key, value})
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*entry).key)
+	}
+}
+
+func (c *Cache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.order.Len()
+}
@@ -0,0 +1,207 @@
+package groot
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/graphql-go/graphql"
+)
+
+// graphql-go resolves introspection queries with global types, so what they
+// get wrong is fixed in the result of each request instead, which keeps the
+// fixes to schemas built by groot
+
+// introspection records the values of introspection fields which are fixed
+// in the result of a request
+type introspection struct {
+	// inputValues are the arguments and input fields returned by args and
+	// inputFields fields, by the path of the field
+	inputValues map[string]interface{}
+	// defaultValues are the paths of defaultValue fields, which graphql-go
+	// prints as strings for enum values and input objects
+	defaultValues [][]interface{}
+
+	// ctx is the context of the request, which rules of fields are checked in
+	ctx context.Context
+	// fieldRules are the rules of fields hidden from requests they deny
+	fieldRules map[*graphql.FieldDefinition]FieldRule
+	// fieldLists are the lists of fields returned by fields fields, which
+	// fields denied by their rules are removed from
+	fieldLists []introspectedFields
+}
+
+type introspectedFields struct {
+	path   []interface{}
+	fields []*graphql.FieldDefinition
+}
+
+// resolveIntrospectionField records the value of an introspection field if
+// it's needed to fix the result, and returns the finish func of the field
+func (collector *resultCollector) resolveIntrospectionField(info *graphql.ResolveInfo) graphql.ResolveFieldFinishFunc {
+	parentType := info.ParentType.Name()
+	switch {
+	case info.FieldName == "args" && (parentType == "__Field" || parentType == "__Directive"),
+		info.FieldName == "inputFields" && parentType == "__Type":
+		key := fmt.Sprint(info.Path.AsArray())
+		return func(value interface{}, err error) {
+			collector.mu.Lock()
+			defer collector.mu.Unlock()
+
+			if collector.introspection.inputValues == nil {
+				collector.introspection.inputValues = map[string]interface{}{}
+			}
+
+			collector.introspection.inputValues[key] = value
+		}
+
+	case info.FieldName == "fields" && parentType == "__Type" && len(collector.introspection.fieldRules) > 0:
+		path := info.Path.AsArray()
+		return func(value interface{}, err error) {
+			fields, ok := value.([]*graphql.FieldDefinition)
+			if !ok {
+				return
+			}
+
+			collector.mu.Lock()
+			defer collector.mu.Unlock()
+
+			collector.introspection.fieldLists = append(collector.introspection.fieldLists, introspectedFields{path, fields})
+		}
+
+	case info.FieldName == "defaultValue" && parentType == "__InputValue":
+		collector.mu.Lock()
+		defer collector.mu.Unlock()
+
+		path := info.Path.AsArray()
+		collector.introspection.defaultValues = append(collector.introspection.defaultValues, path)
+	}
+
+	return func(value interface{}, err error) {}
+}
+
+// fix fixes the introspection fields in the data of a result
+func (introspection *introspection) fix(data interface{}) {
+	for _, path := range introspection.defaultValues {
+		// the path of a default value is the path of the list of input values
+		// followed by the index of the input value and the field
+		end := len(path) - 2
+		if end < 0 {
+			continue
+		}
+
+		index, ok := path[end].(int)
+		inputValues := reflect.ValueOf(introspection.inputValues[fmt.Sprint(path[:end])])
+		if !ok || inputValues.Kind() != reflect.Slice || index >= inputValues.Len() {
+			continue
+		}
+
+		switch inputValue := inputValues.Index(index).Interface().(type) {
+		case *graphql.Argument:
+			setResultValue(data, path, printDefaultValue(inputValue.DefaultValue, inputValue.Type))
+		case *graphql.InputObjectField:
+			setResultValue(data, path, printDefaultValue(inputValue.DefaultValue, inputValue.Type))
+		}
+	}
+
+	introspection.hideFields(data)
+}
+
+// hideFields removes fields denied by their rules from the lists of fields.
+// Removing a field shifts the paths of the lists nested in the fields after
+// it, so nested lists are filtered before the lists they're in.
+func (introspection *introspection) hideFields(data interface{}) {
+	sort.SliceStable(introspection.fieldLists, func(i, j int) bool {
+		return len(introspection.fieldLists[i].path) > len(introspection.fieldLists[j].path)
+	})
+
+	denied := map[*graphql.FieldDefinition]bool{}
+	isDenied := func(field *graphql.FieldDefinition) bool {
+		rule, ok := introspection.fieldRules[field]
+		if !ok {
+			return false
+		}
+
+		if _, ok := denied[field]; !ok {
+			denied[field] = rule(introspection.ctx) != nil
+		}
+
+		return denied[field]
+	}
+
+	for _, fieldList := range introspection.fieldLists {
+		list, ok := getResultValue(data, fieldList.path).([]interface{})
+		if !ok || len(list) != len(fieldList.fields) {
+			continue
+		}
+
+		visible := []interface{}{}
+		for i, field := range fieldList.fields {
+			if !isDenied(field) {
+				visible = append(visible, list[i])
+			}
+		}
+
+		setResultValue(data, fieldList.path, visible)
+	}
+}
+
+// getResultValue returns the value at a path in the data of a result
+func getResultValue(data interface{}, path []interface{}) interface{} {
+	for _, key := range path {
+		switch key := key.(type) {
+		case string:
+			object, ok := data.(map[string]interface{})
+			if !ok {
+				return nil
+			}
+
+			data = object[key]
+
+		case int:
+			list, ok := data.([]interface{})
+			if !ok || key >= len(list) {
+				return nil
+			}
+
+			data = list[key]
+		}
+	}
+
+	return data
+}
+
+// setResultValue replaces the value at a path in the data of a result
+func setResultValue(data interface{}, path []interface{}, value interface{}) {
+	for i, key := range path {
+		isLast := i == len(path)-1
+		switch key := key.(type) {
+		case string:
+			object, ok := data.(map[string]interface{})
+			if !ok {
+				return
+			}
+
+			if isLast {
+				object[key] = value
+				return
+			}
+
+			data = object[key]
+
+		case int:
+			list, ok := data.([]interface{})
+			if !ok || key >= len(list) {
+				return
+			}
+
+			if isLast {
+				list[key] = value
+				return
+			}
+
+			data = list[key]
+		}
+	}
+}
@@ -0,0 +1,252 @@
+// Package metrics records the number, latency and errors of operations, and
+// the latency of resolvers, with a pluggable Recorder. The prometheus package
+// implements a Recorder with Prometheus metrics.
+package metrics
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/shreyas44/groot"
+)
+
+const (
+	// OtherOperation is the name operations are recorded with if their name
+	// isn't in Config.Operations, or if there are already MaxOperations names
+	OtherOperation = "other"
+	// DefaultMaxOperations is the number of operation names recorded if
+	// Config.MaxOperations isn't set
+	DefaultMaxOperations = 100
+)
+
+// Recorder records the metrics collected by the extension
+type Recorder interface {
+	// ObserveOperation is called after an operation is executed, or fails to
+	// parse or validate
+	ObserveOperation(operation Operation)
+	// ObserveResolver is called after the resolver of a field returns
+	ObserveResolver(resolver Resolver)
+}
+
+// Operation is the metrics of an operation
+type Operation struct {
+	// Name is the name of the operation, which is empty if the request doesn't
+	// have an operation name, or OtherOperation if the name isn't recorded
+	Name     string
+	Duration time.Duration
+	// Errors is the number of errors in the response
+	Errors int
+}
+
+// Resolver is the metrics of a call to the resolver of a field
+type Resolver struct {
+	// ParentType is the name of the type the field is defined on
+	ParentType string
+	// Field is the name of the field
+	Field    string
+	Duration time.Duration
+	Err      error
+}
+
+type Config struct {
+	Recorder Recorder
+	// Operations are the names of the operations recorded by their name, any
+	// other operation is recorded as OtherOperation. Operation names are sent
+	// by clients, so they're limited to keep the number of metrics bounded.
+	Operations []string
+	// MaxOperations is the number of operation names recorded if Operations
+	// isn't set, DefaultMaxOperations by default. Names are recorded in the
+	// order operations with them are first executed without errors, and
+	// operations with any other name are recorded as OtherOperation.
+	MaxOperations int
+}
+
+type extension struct {
+	recorder Recorder
+	names    *operationNames
+}
+
+// NewExtension returns an extension to add to groot.SchemaConfig.Extensions,
+// which records metrics with the recorder. Only fields with a resolver method
+// are recorded, since fields resolved from struct fields are never slow.
+func NewExtension(config Config) groot.FieldExtension {
+	names := &operationNames{
+		names: map[string]bool{},
+		max:   config.MaxOperations,
+	}
+
+	if names.max <= 0 {
+		names.max = DefaultMaxOperations
+	}
+
+	if len(config.Operations) != 0 {
+		names.isAllowlist = true
+		for _, name := range config.Operations {
+			names.names[name] = true
+		}
+	}
+
+	return &extension{config.Recorder, names}
+}
+
+// operationNames are the operation names which are recorded
+type operationNames struct {
+	mu          sync.RWMutex
+	names       map[string]bool
+	max         int
+	isAllowlist bool
+}
+
+// get returns the name an operation is recorded with. The name is added to
+// the recorded names if add is true and there are less than max names.
+func (names *operationNames) get(name string, add bool) string {
+	if name == "" {
+		return ""
+	}
+
+	names.mu.RLock()
+	ok := names.names[name]
+	names.mu.RUnlock()
+
+	if ok {
+		return name
+	}
+
+	if names.isAllowlist || !add {
+		return OtherOperation
+	}
+
+	names.mu.Lock()
+	defer names.mu.Unlock()
+
+	if !names.names[name] && len(names.names) >= names.max {
+		return OtherOperation
+	}
+
+	names.names[name] = true
+	return name
+}
+
+type requestKey struct{}
+
+type request struct {
+	once  sync.Once
+	start time.Time
+	// name is the operation name of the request, replaced by the name of the
+	// executed operation once its first field is resolved
+	mu   sync.Mutex
+	name string
+}
+
+func (req *request) setName(name string) {
+	req.mu.Lock()
+	defer req.mu.Unlock()
+	req.name = name
+}
+
+func (req *request) getName() string {
+	req.mu.Lock()
+	defer req.mu.Unlock()
+	return req.name
+}
+
+func requestFromContext(ctx context.Context) *request {
+	if ctx == nil {
+		return nil
+	}
+
+	req, _ := ctx.Value(requestKey{}).(*request)
+	return req
+}
+
+// observe records the operation of a request once, since it either fails to
+// parse or validate, or is executed. Only operations executed without errors
+// add their name to the recorded names, so made up names aren't recorded.
+func (ext *extension) observe(ctx context.Context, errors int, executed bool) {
+	req := requestFromContext(ctx)
+	if req == nil {
+		return
+	}
+
+	req.once.Do(func() {
+		ext.recorder.ObserveOperation(Operation{
+			Name:     ext.names.get(req.getName(), executed && errors == 0),
+			Duration: time.Since(req.start),
+			Errors:   errors,
+		})
+	})
+}
+
+func (ext *extension) Init(ctx context.Context, p *graphql.Params) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	return context.WithValue(ctx, requestKey{}, &request{
+		name:  p.OperationName,
+		start: time.Now(),
+	})
+}
+
+func (ext *extension) Name() string {
+	return "metrics"
+}
+
+func (ext *extension) ParseDidStart(ctx context.Context) (context.Context, graphql.ParseFinishFunc) {
+	return ctx, func(err error) {
+		if err != nil {
+			ext.observe(ctx, 1, false)
+		}
+	}
+}
+
+func (ext *extension) ValidationDidStart(ctx context.Context) (context.Context, graphql.ValidationFinishFunc) {
+	return ctx, func(errs []gqlerrors.FormattedError) {
+		if len(errs) != 0 {
+			ext.observe(ctx, len(errs), false)
+		}
+	}
+}
+
+func (ext *extension) ExecutionDidStart(ctx context.Context) (context.Context, graphql.ExecutionFinishFunc) {
+	return ctx, func(result *graphql.Result) {
+		ext.observe(ctx, len(result.Errors), true)
+	}
+}
+
+func (ext *extension) ResolveFieldDidStart(ctx context.Context, info *graphql.ResolveInfo) (context.Context, graphql.ResolveFieldFinishFunc) {
+	if req := requestFromContext(ctx); req != nil && info != nil {
+		if name := groot.OperationName(*info); name != "" {
+			req.setName(name)
+		}
+	}
+
+	return ctx, func(v interface{}, err error) {}
+}
+
+func (ext *extension) HasResult() bool {
+	return false
+}
+
+func (ext *extension) GetResult(ctx context.Context) interface{} {
+	return nil
+}
+
+func (ext *extension) FieldDidStart(ctx context.Context, field groot.FieldInfo) (context.Context, groot.FieldFinishFunc) {
+	if field.Resolver == nil {
+		return ctx, func(interface{}, error) {}
+	}
+
+	start := time.Now()
+	return ctx, func(result interface{}, err error) {
+		ext.recorder.ObserveResolver(Resolver{
+			ParentType: field.Info.ParentType.Name(),
+			Field:      field.Info.FieldName,
+			Duration:   time.Since(start),
+			Err:        err,
+		})
+	}
+}
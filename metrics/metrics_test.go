@@ -0,0 +1,116 @@
+package metrics_test
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/shreyas44/groot"
+	"github.com/shreyas44/groot/metrics"
+)
+
+type Query struct {
+	Hello string   `json:"hello"`
+	Items []string `json:"items"`
+}
+
+func (query Query) ResolveHello() (string, error) {
+	return "world", nil
+}
+
+func (query Query) ResolveItems() ([]string, []error) {
+	return []string{"a", "b"}, []error{groot.ErrorAt(errors.New("failed"), 1)}
+}
+
+type recorder struct {
+	mu         sync.Mutex
+	operations []metrics.Operation
+}
+
+func (r *recorder) ObserveOperation(operation metrics.Operation) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.operations = append(r.operations, operation)
+}
+
+func (r *recorder) ObserveResolver(resolver metrics.Resolver) {}
+
+func execute(t *testing.T, config metrics.Config, requests ...groot.Request) []metrics.Operation {
+	r := &recorder{}
+	config.Recorder = r
+	executor, err := groot.NewExecutor(groot.SchemaConfig{
+		Query:      groot.MustParseObject(Query{}),
+		Extensions: []graphql.Extension{metrics.NewExtension(config)},
+	}, groot.ExecutorConfig{})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, request := range requests {
+		executor.Execute(request)
+	}
+
+	return r.operations
+}
+
+func assertOperations(t *testing.T, operations []metrics.Operation, expected ...string) {
+	t.Helper()
+	if len(operations) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, operations)
+	}
+
+	for i := range expected {
+		if operations[i].Name != expected[i] {
+			t.Fatalf("expected %v, got %v", expected, operations)
+		}
+	}
+}
+
+func TestOperationAllowlist(t *testing.T) {
+	operations := execute(t,
+		metrics.Config{Operations: []string{"Hello"}},
+		groot.Request{Query: "query Hello { hello }", OperationName: "Hello"},
+		groot.Request{Query: "query Other { hello }", OperationName: "Other"},
+	)
+
+	assertOperations(t, operations, "Hello", metrics.OtherOperation)
+}
+
+func TestMaxOperations(t *testing.T) {
+	operations := execute(t,
+		metrics.Config{MaxOperations: 1},
+		// made up names of invalid requests aren't recorded
+		groot.Request{Query: "{ hello }", OperationName: "MadeUp"},
+		groot.Request{Query: "query First { hello }", OperationName: "First"},
+		groot.Request{Query: "query Second { hello }", OperationName: "Second"},
+		groot.Request{Query: "query First { hello }", OperationName: "First"},
+	)
+
+	assertOperations(t, operations, metrics.OtherOperation, "First", metrics.OtherOperation, "First")
+}
+
+func TestOperationNameFromDocument(t *testing.T) {
+	operations := execute(t,
+		metrics.Config{},
+		groot.Request{Query: "query Hello { hello }"},
+	)
+
+	assertOperations(t, operations, "Hello")
+}
+
+func TestOperationErrorsIncludeFieldErrors(t *testing.T) {
+	requests := make([]groot.Request, 100)
+	for i := range requests {
+		requests[i] = groot.Request{Query: "{ items }"}
+	}
+
+	// the errors of fields are added to the result by the finish func of
+	// another extension, which graphql-go calls in a random order
+	for _, operation := range execute(t, metrics.Config{}, requests...) {
+		if operation.Errors != 1 {
+			t.Fatalf("expected 1 error, got %d", operation.Errors)
+		}
+	}
+}
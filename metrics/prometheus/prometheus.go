@@ -0,0 +1,115 @@
+// Package prometheus implements metrics.Recorder with Prometheus metrics
+package prometheus
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/shreyas44/groot/metrics"
+)
+
+type Config struct {
+	// Namespace is prepended to the names of the metrics, "graphql" by default
+	Namespace string
+	// Registerer registers the metrics, prometheus.DefaultRegisterer by default
+	Registerer prometheus.Registerer
+	// OperationBuckets and ResolverBuckets are the buckets of the latency
+	// histograms, prometheus.DefBuckets by default
+	OperationBuckets []float64
+	ResolverBuckets  []float64
+}
+
+// Recorder records the following metrics, with the default namespace:
+//
+//	graphql_operations_total{operation}
+//	graphql_operation_errors_total{operation}
+//	graphql_operation_duration_seconds{operation}
+//	graphql_resolver_duration_seconds{type, field}
+//	graphql_resolver_errors_total{type, field}
+type Recorder struct {
+	operations        *prometheus.CounterVec
+	operationErrors   *prometheus.CounterVec
+	operationDuration *prometheus.HistogramVec
+	resolverDuration  *prometheus.HistogramVec
+	resolverErrors    *prometheus.CounterVec
+}
+
+// NewRecorder returns a recorder to set as metrics.Config.Recorder, and
+// registers its metrics
+func NewRecorder(config Config) (*Recorder, error) {
+	if config.Namespace == "" {
+		config.Namespace = "graphql"
+	}
+
+	if config.Registerer == nil {
+		config.Registerer = prometheus.DefaultRegisterer
+	}
+
+	recorder := &Recorder{
+		operations: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: config.Namespace,
+			Name:      "operations_total",
+			Help:      "Number of operations executed.",
+		}, []string{"operation"}),
+		operationErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: config.Namespace,
+			Name:      "operation_errors_total",
+			Help:      "Number of errors in the responses of operations.",
+		}, []string{"operation"}),
+		operationDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: config.Namespace,
+			Name:      "operation_duration_seconds",
+			Help:      "Time taken to parse, validate and execute operations.",
+			Buckets:   config.OperationBuckets,
+		}, []string{"operation"}),
+		resolverDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: config.Namespace,
+			Name:      "resolver_duration_seconds",
+			Help:      "Time taken by the resolvers of fields.",
+			Buckets:   config.ResolverBuckets,
+		}, []string{"type", "field"}),
+		resolverErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: config.Namespace,
+			Name:      "resolver_errors_total",
+			Help:      "Number of errors returned by the resolvers of fields.",
+		}, []string{"type", "field"}),
+	}
+
+	collectors := []prometheus.Collector{
+		recorder.operations,
+		recorder.operationErrors,
+		recorder.operationDuration,
+		recorder.resolverDuration,
+		recorder.resolverErrors,
+	}
+
+	for _, collector := range collectors {
+		if err := config.Registerer.Register(collector); err != nil {
+			return nil, err
+		}
+	}
+
+	return recorder, nil
+}
+
+// MustNewRecorder is like NewRecorder, but panics if the metrics cannot be
+// registered
+func MustNewRecorder(config Config) *Recorder {
+	recorder, err := NewRecorder(config)
+	if err != nil {
+		panic(err)
+	}
+
+	return recorder
+}
+
+func (r *Recorder) ObserveOperation(operation metrics.Operation) {
+	r.operations.WithLabelValues(operation.Name).Inc()
+	r.operationErrors.WithLabelValues(operation.Name).Add(float64(operation.Errors))
+	r.operationDuration.WithLabelValues(operation.Name).Observe(operation.Duration.Seconds())
+}
+
+func (r *Recorder) ObserveResolver(resolver metrics.Resolver) {
+	r.resolverDuration.WithLabelValues(resolver.ParentType, resolver.Field).Observe(resolver.Duration.Seconds())
+	if resolver.Err != nil {
+		r.resolverErrors.WithLabelValues(resolver.ParentType, resolver.Field).Inc()
+	}
+}
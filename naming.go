@@ -0,0 +1,116 @@
+package groot
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/shreyas44/groot/parser"
+)
+
+// NameFunc converts the name of a Go struct field or enum value to the name
+// used in the GraphQL schema
+type NameFunc func(name string) string
+
+// CamelCase converts names like CreatedAt and URLPath to createdAt and urlPath
+func CamelCase(name string) string {
+	words := splitWords(name)
+	for i, word := range words {
+		if i == 0 {
+			words[i] = strings.ToLower(word)
+		} else if isUpper(word) {
+			// keep initialisms like ID in UserID as they are
+			words[i] = word
+		} else {
+			words[i] = strings.ToUpper(word[:1]) + word[1:]
+		}
+	}
+
+	return strings.Join(words, "")
+}
+
+// SnakeCase converts names like CreatedAt and URLPath to created_at and url_path
+func SnakeCase(name string) string {
+	return strings.ToLower(strings.Join(splitWords(name), "_"))
+}
+
+// ScreamingSnakeCase converts names like CreatedAt and URLPath to CREATED_AT
+// and URL_PATH, which is the convention for enum values
+func ScreamingSnakeCase(name string) string {
+	return strings.ToUpper(strings.Join(splitWords(name), "_"))
+}
+
+// splitWords splits a name into words on underscores, hyphens, spaces and
+// changes in case, treating a run of upper case letters as a single word
+func splitWords(name string) []string {
+	var (
+		words = []string{}
+		runes = []rune(name)
+		start = 0
+	)
+
+	addWord := func(end int) {
+		if word := strings.Trim(string(runes[start:end]), "_- "); word != "" {
+			words = append(words, word)
+		}
+
+		start = end
+	}
+
+	for i := 1; i < len(runes); i++ {
+		prev, curr := runes[i-1], runes[i]
+
+		switch {
+		case curr == '_' || curr == '-' || curr == ' ':
+			addWord(i)
+		case unicode.IsLower(prev) && unicode.IsUpper(curr):
+			addWord(i)
+		case unicode.IsDigit(prev) && unicode.IsUpper(curr):
+			addWord(i)
+		case unicode.IsUpper(prev) && unicode.IsUpper(curr) && i+1 < len(runes) && unicode.IsLower(runes[i+1]):
+			addWord(i)
+		}
+	}
+
+	addWord(len(runes))
+	return words
+}
+
+func isUpper(s string) bool {
+	for _, r := range s {
+		if unicode.IsLower(r) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (builder *SchemaBuilder) fieldName(field *parser.Field) string {
+	return builder.applyFieldNaming(field.TagName(), field.StructField().Name)
+}
+
+func (builder *SchemaBuilder) argumentName(arg *parser.Argument) string {
+	return builder.applyFieldNaming(arg.TagName(), arg.StructField().Name)
+}
+
+func (builder *SchemaBuilder) enumValueName(value parser.EnumValue) string {
+	if builder.enumValueNaming == nil {
+		return value.Name
+	}
+
+	return builder.enumValueNaming(value.Name)
+}
+
+// applyFieldNaming returns the name set with the graphql or json tag if there
+// is one, and applies the field naming strategy to the Go name otherwise
+func (builder *SchemaBuilder) applyFieldNaming(tagName string, goName string) string {
+	if tagName != "" {
+		return tagName
+	}
+
+	if builder.fieldNaming == nil {
+		return goName
+	}
+
+	return builder.fieldNaming(goName)
+}
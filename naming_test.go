@@ -0,0 +1,149 @@
+package groot_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/shreyas44/groot"
+)
+
+func TestNameFuncs(t *testing.T) {
+	tests := []struct {
+		name                string
+		camel, snake, upper string
+	}{
+		{"CreatedAt", "createdAt", "created_at", "CREATED_AT"},
+		{"URLPath", "urlPath", "url_path", "URL_PATH"},
+		{"UserID", "userID", "user_id", "USER_ID"},
+		{"already_snake", "alreadySnake", "already_snake", "ALREADY_SNAKE"},
+		{"Version2Name", "version2Name", "version2_name", "VERSION2_NAME"},
+		{"ID", "id", "id", "ID"},
+	}
+
+	for _, test := range tests {
+		if name := groot.CamelCase(test.name); name != test.camel {
+			t.Errorf("CamelCase(%q): expected %q, got %q", test.name, test.camel, name)
+		}
+
+		if name := groot.SnakeCase(test.name); name != test.snake {
+			t.Errorf("SnakeCase(%q): expected %q, got %q", test.name, test.snake, name)
+		}
+
+		if name := groot.ScreamingSnakeCase(test.name); name != test.upper {
+			t.Errorf("ScreamingSnakeCase(%q): expected %q, got %q", test.name, test.upper, name)
+		}
+	}
+}
+
+type NamingColor int
+
+const (
+	NamingColorLightBlue NamingColor = iota
+	NamingColorDarkRed
+)
+
+func (color *NamingColor) EnumValues() []groot.EnumValue {
+	return []groot.EnumValue{
+		{Name: "LightBlue", Value: NamingColorLightBlue},
+		{Name: "DarkRed", Value: NamingColorDarkRed},
+	}
+}
+
+type NamingArgs struct {
+	PageSize int    `default:"10"`
+	SortBy   string `json:"sort" default:"name"`
+	Hidden   string `graphql:"-"`
+	Ignored  string `json:"-"`
+}
+
+type NamingItem struct {
+	CreatedAt  string `json:"createdAt_json"`
+	UpdatedAt  string `graphql:"modified" json:"updatedAt_json"`
+	DeletedAt  string
+	FavColor   NamingColor `json:"favColor"`
+	ListedKeys []string
+}
+
+func (item NamingItem) ResolveListedKeys(args NamingArgs) ([]string, error) {
+	return []string{args.SortBy, strings.Repeat("x", args.PageSize)}, nil
+}
+
+type NamingQuery struct {
+	Item NamingItem
+}
+
+func (query NamingQuery) ResolveItem() (NamingItem, error) {
+	return NamingItem{CreatedAt: "created", UpdatedAt: "updated", DeletedAt: "deleted"}, nil
+}
+
+func TestFieldNaming(t *testing.T) {
+	schema, err := groot.NewSchema(groot.SchemaConfig{
+		Query:           groot.MustParseObject(NamingQuery{}),
+		FieldNaming:     groot.SnakeCase,
+		EnumValueNaming: groot.ScreamingSnakeCase,
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// tag names win over the naming function, the graphql tag over the json
+	// tag, and fields and arguments ignored with either tag aren't exposed
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ item { createdAt_json modified deleted_at favColor listed_keys(page_size: 2, sort: "id") } }`,
+	})
+
+	assertResult(t, result, `{"item":{"createdAt_json":"created","deleted_at":"deleted","favColor":"LIGHT_BLUE","listed_keys":["id","xx"],"modified":"updated"}}`)
+
+	for _, query := range []string{`{ item { listed_keys(hidden: "") } }`, `{ item { listed_keys(ignored: "") } }`, `{ item { updatedAt_json } }`} {
+		result := graphql.Do(graphql.Params{Schema: schema, RequestString: query})
+		if len(result.Errors) == 0 {
+			t.Fatalf("%s: expected an error for a field that isn't exposed", query)
+		}
+	}
+}
+
+type NamingExposedArgs struct {
+	Secret string `json:"-" graphql:"secret"`
+}
+
+type NamingExposedQuery struct {
+	Value string `json:"value"`
+}
+
+func (query NamingExposedQuery) ResolveValue(args NamingExposedArgs) (string, error) {
+	return args.Secret, nil
+}
+
+type NamingExposedObject struct {
+	Secret string `json:"-" graphql:"secret"`
+}
+
+type NamingExposedObjectQuery struct {
+	Object NamingExposedObject `json:"object"`
+}
+
+func (query NamingExposedObjectQuery) ResolveObject() (NamingExposedObject, error) {
+	return NamingExposedObject{Secret: "secret"}, nil
+}
+
+// a field with the json tag set to "-" is exposed with the graphql tag, which
+// arguments decoded with encoding/json cannot support
+func TestIgnoredJSONFieldWithGraphQLName(t *testing.T) {
+	schema, err := groot.NewSchema(groot.SchemaConfig{
+		Query: groot.MustParseObject(NamingExposedObjectQuery{}),
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := graphql.Do(graphql.Params{Schema: schema, RequestString: `{ object { secret } }`})
+	assertResult(t, result, `{"object":{"secret":"secret"}}`)
+
+	if _, err := groot.ParseObject(NamingExposedQuery{}); err == nil {
+		t.Fatal("expected an error for an argument with the json tag set to \"-\" and the graphql tag")
+	}
+}
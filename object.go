@@ -6,25 +6,40 @@ import (
 )
 
 func NewObject(parserObject *parser.Object, builder *SchemaBuilder) *graphql.Object {
-	interfaceCount := len(parserObject.Interfaces())
-	interfaces := make([]*graphql.Interface, interfaceCount)
+	parserInterfaces := []*parser.Interface{}
+	for _, parserInterface := range parserObject.Interfaces() {
+		if builder.isTypeVisible(parserInterface) {
+			parserInterfaces = append(parserInterfaces, parserInterface)
+		}
+	}
+
+	interfaces := make([]*graphql.Interface, len(parserInterfaces))
 	fields := graphql.Fields{}
 
 	object := graphql.NewObject(graphql.ObjectConfig{
-		Name:       parserObject.ReflectType().Name(),
-		Interfaces: interfaces,
-		Fields:     fields,
+		Name:        parserObject.Name(),
+		Description: parserObject.Description(),
+		Interfaces:  interfaces,
+		Fields:      fields,
 	})
 
 	builder.addType(parserObject, object)
 
-	for i, parserInterface := range parserObject.Interfaces() {
+	for i, parserInterface := range parserInterfaces {
 		interface_ := getOrCreateType(parserInterface, builder)
 		interfaces[i] = GetNullable(interface_).(*graphql.Interface)
 	}
 
 	for _, parserField := range parserObject.Fields() {
-		object.AddFieldConfig(parserField.JSONName(), NewField(parserField, builder))
+		if !builder.isFieldVisible(parserField) {
+			continue
+		}
+
+		field := NewField(parserField, builder)
+		builder.hideValues(parserField, field)
+		builder.authorizeField(object, parserField, field)
+		builder.addFieldCost(object, parserField, field)
+		object.AddFieldConfig(field.Name, field)
 	}
 
 	return object
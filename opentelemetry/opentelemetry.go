@@ -0,0 +1,246 @@
+// Package opentelemetry traces requests with OpenTelemetry, creating spans
+// for parsing, validating and executing requests, and for resolving fields.
+package opentelemetry
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/shreyas44/groot"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// InstrumentationName is the name of the tracer spans are created with
+const InstrumentationName = "github.com/shreyas44/groot/opentelemetry"
+
+// Attributes of spans
+const (
+	OperationNameKey = attribute.Key("graphql.operation.name")
+	FieldNameKey     = attribute.Key("graphql.field.name")
+	FieldPathKey     = attribute.Key("graphql.field.path")
+	ParentTypeKey    = attribute.Key("graphql.field.parent_type")
+	FieldTypeKey     = attribute.Key("graphql.field.type")
+)
+
+type Config struct {
+	// TracerProvider creates the tracer spans are created with, the global
+	// provider by default
+	TracerProvider trace.TracerProvider
+	// ResolversOnly only traces fields resolved with a Resolve<Field> method
+	// or a resolver struct
+	ResolversOnly bool
+	// SkipDefaultResolved doesn't trace fields resolved from struct fields
+	// without any method, which are usually too fast to be worth tracing
+	SkipDefaultResolved bool
+}
+
+type extension struct {
+	config Config
+	tracer trace.Tracer
+}
+
+// NewExtension returns an extension to add to groot.SchemaConfig.Extensions
+func NewExtension(config Config) groot.FieldExtension {
+	if config.TracerProvider == nil {
+		config.TracerProvider = otel.GetTracerProvider()
+	}
+
+	return &extension{
+		config: config,
+		tracer: config.TracerProvider.Tracer(InstrumentationName),
+	}
+}
+
+type requestKey struct{}
+
+// request is the state of a request being traced
+type request struct {
+	operationName string
+	// executeSpan is the span of the execution, which the name of the
+	// executed operation is added to once its first field is resolved
+	executeSpan trace.Span
+	nameOnce    sync.Once
+	// fieldContexts are the contexts of the spans of fields by their path,
+	// which are the parents of the spans of their child fields
+	fieldContexts sync.Map
+}
+
+func requestFromContext(ctx context.Context) *request {
+	if ctx == nil {
+		return nil
+	}
+
+	req, _ := ctx.Value(requestKey{}).(*request)
+	return req
+}
+
+func (ext *extension) Init(ctx context.Context, p *graphql.Params) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	return context.WithValue(ctx, requestKey{}, &request{operationName: p.OperationName})
+}
+
+func (ext *extension) Name() string {
+	return "opentelemetry"
+}
+
+func (ext *extension) ParseDidStart(ctx context.Context) (context.Context, graphql.ParseFinishFunc) {
+	_, span := ext.tracer.Start(ctx, "graphql.parse")
+	return ctx, func(err error) {
+		recordError(span, err)
+		span.End()
+	}
+}
+
+func (ext *extension) ValidationDidStart(ctx context.Context) (context.Context, graphql.ValidationFinishFunc) {
+	_, span := ext.tracer.Start(ctx, "graphql.validate")
+	return ctx, func(errs []gqlerrors.FormattedError) {
+		recordErrors(span, errs)
+		span.End()
+	}
+}
+
+func (ext *extension) ExecutionDidStart(ctx context.Context) (context.Context, graphql.ExecutionFinishFunc) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	attributes := []attribute.KeyValue{}
+	req := requestFromContext(ctx)
+	if req != nil && req.operationName != "" {
+		attributes = append(attributes, OperationNameKey.String(req.operationName))
+	}
+
+	ctx, span := ext.tracer.Start(ctx, "graphql.execute", trace.WithAttributes(attributes...))
+	if req != nil {
+		req.executeSpan = span
+	}
+
+	return ctx, func(result *graphql.Result) {
+		recordErrors(span, result.Errors)
+		span.End()
+	}
+}
+
+func (ext *extension) ResolveFieldDidStart(ctx context.Context, info *graphql.ResolveInfo) (context.Context, graphql.ResolveFieldFinishFunc) {
+	// the operation name of the request is empty for documents with a single
+	// operation, so it's taken from the document instead
+	if req := requestFromContext(ctx); req != nil && req.executeSpan != nil && info != nil {
+		req.nameOnce.Do(func() {
+			if name := groot.OperationName(*info); name != "" {
+				req.executeSpan.SetAttributes(OperationNameKey.String(name))
+			}
+		})
+	}
+
+	return ctx, func(v interface{}, err error) {}
+}
+
+func (ext *extension) HasResult() bool {
+	return false
+}
+
+func (ext *extension) GetResult(ctx context.Context) interface{} {
+	return nil
+}
+
+func (ext *extension) FieldDidStart(ctx context.Context, field groot.FieldInfo) (context.Context, groot.FieldFinishFunc) {
+	if ext.config.ResolversOnly && field.Resolver == nil {
+		return ctx, func(interface{}, error) {}
+	}
+
+	if ext.config.SkipDefaultResolved && field.IsDefaultResolved() {
+		return ctx, func(interface{}, error) {}
+	}
+
+	info := field.Info
+	path := info.Path.AsArray()
+	attributes := []attribute.KeyValue{
+		FieldNameKey.String(info.FieldName),
+		FieldPathKey.String(pathString(path)),
+		ParentTypeKey.String(info.ParentType.Name()),
+		FieldTypeKey.String(info.ReturnType.String()),
+	}
+
+	if field.Resolver != nil {
+		method := field.Resolver.ReflectMethod()
+		attributes = append(attributes,
+			semconv.CodeNamespaceKey.String(method.Type.In(0).String()),
+			semconv.CodeFunctionKey.String(method.Name),
+		)
+	}
+
+	// the span of the closest traced ancestor field is the parent of the span,
+	// since graphql-go resolves every field with the context of the request
+	req := requestFromContext(ctx)
+	if req != nil {
+		for parent := parentFieldPath(path); len(parent) != 0; parent = parentFieldPath(parent) {
+			if parentCtx, ok := req.fieldContexts.Load(pathString(parent)); ok {
+				ctx = parentCtx.(context.Context)
+				break
+			}
+		}
+	}
+
+	name := fmt.Sprintf("%s.%s", info.ParentType.Name(), info.FieldName)
+	ctx, span := ext.tracer.Start(ctx, name, trace.WithAttributes(attributes...))
+	if req != nil {
+		req.fieldContexts.Store(pathString(path), ctx)
+	}
+
+	return ctx, func(result interface{}, err error) {
+		recordError(span, err)
+		span.End()
+	}
+}
+
+func recordError(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+}
+
+func recordErrors(span trace.Span, errs []gqlerrors.FormattedError) {
+	for _, err := range errs {
+		span.RecordError(err)
+	}
+
+	if len(errs) != 0 {
+		span.SetStatus(codes.Error, errs[0].Message)
+	}
+}
+
+// parentFieldPath returns the path of the parent field of a field, skipping
+// the indices of lists
+func parentFieldPath(path []interface{}) []interface{} {
+	end := len(path) - 1
+	for end > 0 {
+		if _, isIndex := path[end-1].(int); !isIndex {
+			break
+		}
+
+		end--
+	}
+
+	return path[:end]
+}
+
+func pathString(path []interface{}) string {
+	parts := make([]string, len(path))
+	for i, key := range path {
+		parts[i] = fmt.Sprint(key)
+	}
+
+	return strings.Join(parts, ".")
+}
@@ -0,0 +1,151 @@
+package opentelemetry_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/shreyas44/groot"
+	"github.com/shreyas44/groot/opentelemetry"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+type Profile struct {
+	Avatar string `json:"avatar"`
+}
+
+func (profile Profile) ResolveAvatar() (string, error) {
+	return "avatar.png", nil
+}
+
+type User struct {
+	Profile Profile `json:"profile"`
+}
+
+type Query struct {
+	User  User     `json:"user"`
+	Slow  string   `json:"slow" timeout:"10ms"`
+	Items []string `json:"items"`
+}
+
+func (query Query) ResolveItems() ([]string, []error) {
+	return []string{"a", "b"}, []error{groot.ErrorAt(errors.New("failed"), 1)}
+}
+
+func (query Query) ResolveUser() (User, error) {
+	return User{}, nil
+}
+
+func (query Query) ResolveSlow(ctx context.Context) (string, error) {
+	// the resolver ignores ctx, and only returns after the test finishes
+	<-ctx.Value(releaseKey{}).(chan struct{})
+	return "slow", nil
+}
+
+type releaseKey struct{}
+
+func newExecutor(t *testing.T, config opentelemetry.Config) (*groot.Executor, *tracetest.InMemoryExporter) {
+	exporter := tracetest.NewInMemoryExporter()
+	config.TracerProvider = sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	executor, err := groot.NewExecutor(groot.SchemaConfig{
+		Query:      groot.MustParseObject(Query{}),
+		Extensions: []graphql.Extension{opentelemetry.NewExtension(config)},
+	}, groot.ExecutorConfig{})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return executor, exporter
+}
+
+func findSpan(t *testing.T, exporter *tracetest.InMemoryExporter, name string) tracetest.SpanStub {
+	t.Helper()
+	for _, span := range exporter.GetSpans() {
+		if span.Name == name {
+			return span
+		}
+	}
+
+	t.Fatalf("no span named %s", name)
+	return tracetest.SpanStub{}
+}
+
+func TestTimedOutFieldSpan(t *testing.T) {
+	executor, exporter := newExecutor(t, opentelemetry.Config{})
+	release := make(chan struct{})
+	defer close(release)
+
+	result := executor.Execute(groot.Request{
+		Query:   "{ slow }",
+		Context: context.WithValue(context.Background(), releaseKey{}, release),
+	})
+
+	if len(result.Errors) != 1 {
+		t.Fatalf("expected a timeout error, got %v", result.Errors)
+	}
+
+	// the span ends with the timeout error, before the resolver returns
+	span := findSpan(t, exporter, "Query.slow")
+	if span.Status.Code != codes.Error || len(span.Events) == 0 {
+		t.Fatalf("expected the span to record the timeout error, got %+v", span.Status)
+	}
+}
+
+func TestParentOfSkippedField(t *testing.T) {
+	executor, exporter := newExecutor(t, opentelemetry.Config{ResolversOnly: true})
+
+	result := executor.Execute(groot.Request{Query: "{ user { profile { avatar } } }"})
+	if len(result.Errors) != 0 {
+		t.Fatal(result.Errors)
+	}
+
+	user := findSpan(t, exporter, "Query.user")
+	avatar := findSpan(t, exporter, "Profile.avatar")
+	if avatar.Parent.SpanID() != user.SpanContext.SpanID() {
+		t.Fatal("expected the span of the closest traced ancestor to be the parent")
+	}
+
+	for _, span := range exporter.GetSpans() {
+		if span.Name == "User.profile" {
+			t.Fatal("expected User.profile not to be traced")
+		}
+	}
+}
+
+func TestExecuteSpanRecordsFieldErrors(t *testing.T) {
+	executor, exporter := newExecutor(t, opentelemetry.Config{})
+
+	// the errors of fields are added to the result by the finish func of
+	// another extension, which graphql-go calls in a random order
+	for i := 0; i < 100; i++ {
+		exporter.Reset()
+		result := executor.Execute(groot.Request{Query: "query Items { items }"})
+		if len(result.Errors) != 1 {
+			t.Fatalf("expected a partial result with 1 error, got %v", result.Errors)
+		}
+
+		span := findSpan(t, exporter, "graphql.execute")
+		if span.Status.Code != codes.Error || len(span.Events) != 1 {
+			t.Fatalf("expected the span to record the field error, got %+v", span.Status)
+		}
+	}
+}
+
+func TestExecuteSpanOperationName(t *testing.T) {
+	executor, exporter := newExecutor(t, opentelemetry.Config{})
+	executor.Execute(groot.Request{Query: "query Items { items }"})
+
+	span := findSpan(t, exporter, "graphql.execute")
+	for _, attribute := range span.Attributes {
+		if attribute.Key == opentelemetry.OperationNameKey && attribute.Value.AsString() == "Items" {
+			return
+		}
+	}
+
+	t.Fatalf("expected the operation name Items, got %v", span.Attributes)
+}
@@ -30,6 +30,12 @@ func ParseScalar(i interface{}) (*parser.Scalar, error) {
 	return parser.ParseScalar(reflect.TypeOf(i))
 }
 
+// ParseResolvers parses a struct with resolvers for the fields of an object,
+// usually holding the dependencies the resolvers need
+func ParseResolvers(i interface{}) (*parser.ResolverSet, error) {
+	return parser.ParseResolvers(reflect.ValueOf(i))
+}
+
 func MustParseObject(i interface{}) *parser.Object {
 	object, err := ParseObject(i)
 	if err != nil {
@@ -83,3 +89,12 @@ func MustParseEnum(i interface{}) *parser.Enum {
 
 	return enum
 }
+
+func MustParseResolvers(i interface{}) *parser.ResolverSet {
+	resolvers, err := ParseResolvers(i)
+	if err != nil {
+		panic(err)
+	}
+
+	return resolvers
+}
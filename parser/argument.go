@@ -11,28 +11,62 @@ type Argument struct {
 	input        *Input
 	type_        Type
 	jsonName     string
+	graphqlName  string
+	omitEmpty    bool
+	asString     bool
 	defaultValue string
 	description  string
+	visibility   string
+	multiplier   bool
 }
 
 func NewArgument(input *Input, field reflect.StructField) (*Argument, error) {
-	if field.Tag.Get("json") == "-" || !field.IsExported() {
+	if !field.IsExported() || isFieldIgnored(field) {
 		return nil, nil
 	}
 
+	// arguments are decoded from JSON, so a field ignored by encoding/json
+	// can't be exposed with the graphql tag like fields of objects
+	if field.Tag.Get("json") == "-" {
+		return nil, fmt.Errorf(
+			"argument %s on struct %s cannot be exposed with the graphql tag since its json tag is \"-\"",
+			field.Name,
+			input.reflectType.Name(),
+		)
+	}
+
+	jsonTag := parseJSONTag(field)
 	argument := &Argument{
 		structField:  field,
 		input:        input,
 		description:  field.Tag.Get("description"),
-		jsonName:     field.Tag.Get("json"),
+		jsonName:     jsonTag.name,
+		graphqlName:  field.Tag.Get("graphql"),
+		omitEmpty:    jsonTag.omitEmpty,
+		asString:     jsonTag.asString,
 		defaultValue: field.Tag.Get("default"),
+		visibility:   field.Tag.Get("visibility"),
+	}
+
+	multiplier, err := parseBoolTag(input.reflectType, field, "multiplier")
+	if err != nil {
+		return nil, err
+	}
+
+	argument.multiplier = multiplier
+
+	// arguments with the string option are received as strings and decoded
+	// by encoding/json
+	reflectType := field.Type
+	if jsonTag.asString {
+		reflectType = getStringifiedType(field.Type)
 	}
 
-	if err := validateArgumentType(argument); err != nil {
+	if err := validateArgumentType(argument, reflectType); err != nil {
 		return nil, err
 	}
 
-	type_, err := getOrCreateArgumentType(field.Type)
+	type_, err := getOrCreateArgumentType(reflectType)
 	if err != nil {
 		return nil, err
 	}
@@ -59,6 +93,19 @@ func (arg *Argument) Description() string {
 	return arg.description
 }
 
+// Multiplier reports whether the multiplier tag of the argument is set, in
+// which case the argument is the size of the list the field returns, and the
+// cost of the field is multiplied by it
+func (arg *Argument) Multiplier() bool {
+	return arg.multiplier
+}
+
+// Visibility returns the visibility set with the visibility tag, which drops
+// the argument from schemas which don't include the visibility
+func (arg *Argument) Visibility() string {
+	return arg.visibility
+}
+
 func (arg *Argument) JSONName() string {
 	if arg.jsonName != "" {
 		return arg.jsonName
@@ -67,6 +114,28 @@ func (arg *Argument) JSONName() string {
 	return arg.structField.Name
 }
 
+// OmitEmpty reports whether the json tag of the argument has the omitempty
+// option
+func (arg *Argument) OmitEmpty() bool {
+	return arg.omitEmpty
+}
+
+// AsString reports whether the json tag of the argument has the string
+// option, in which case the argument is received as a String
+func (arg *Argument) AsString() bool {
+	return arg.asString
+}
+
+// TagName returns the name set with the graphql tag, falling back to the
+// json tag. An empty string is returned if neither is set.
+func (arg *Argument) TagName() string {
+	if arg.graphqlName != "" {
+		return arg.graphqlName
+	}
+
+	return arg.jsonName
+}
+
 func (arg *Argument) DefaultValue() string {
 	return arg.defaultValue
 }
@@ -79,8 +148,8 @@ func (arg *Argument) StructField() reflect.StructField {
 	return arg.structField
 }
 
-func validateArgumentType(arg *Argument) error {
-	kind, err := getTypeKind(arg.structField.Type)
+func validateArgumentType(arg *Argument, t reflect.Type) error {
+	kind, err := getTypeKind(t)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,88 @@
+package parser
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// CostMethod is a method named Cost<Field> which returns the cost of a field,
+// optionally based on the arguments of the field
+type CostMethod struct {
+	reflectMethod reflect.Method
+	field         *Field
+}
+
+func NewCostMethod(field *Field) (*CostMethod, error) {
+	var (
+		fieldName  = field.structField.Name
+		methodName = fmt.Sprintf("Cost%s", fieldName)
+		object     = field.Object().ReflectType()
+	)
+
+	method, hasMethod := getMethod(object, methodName)
+	if !hasMethod {
+		return nil, nil
+	}
+
+	if err := validateCostMethod(method, field); err != nil {
+		return nil, err
+	}
+
+	return &CostMethod{method, field}, nil
+}
+
+func (c *CostMethod) ReflectMethod() reflect.Method {
+	return c.reflectMethod
+}
+
+func (c *CostMethod) Field() *Field {
+	return c.field
+}
+
+// AcceptsArgs reports whether the method accepts the arguments of the field
+func (c *CostMethod) AcceptsArgs() bool {
+	return c.reflectMethod.Type.NumIn() == 2
+}
+
+func validateCostMethod(method reflect.Method, field *Field) error {
+	var (
+		funcType   = method.Type
+		structType = getReceiverType(method)
+		intType    = reflect.TypeOf(0)
+	)
+
+	if funcType.NumOut() != 1 || funcType.Out(0) != intType {
+		return fmt.Errorf(
+			"method %s on struct %s expected to return only int",
+			method.Name,
+			structType.Name(),
+		)
+	}
+
+	switch funcType.NumIn() {
+	case 1:
+		return nil
+	case 2:
+		if field.argsInput != nil && funcType.In(1) == field.argsInput.reflectType {
+			return nil
+		}
+
+		// the arguments may be defined by a resolver set, which the schema
+		// builder checks the method against
+		if field.argsInput == nil && field.resolver == nil && funcType.In(1).Kind() == reflect.Struct {
+			return nil
+		}
+	}
+
+	argsType := "no arguments"
+	if field.argsInput != nil {
+		argsType = fmt.Sprintf("no arguments or 1 argument of type (%s)", field.argsInput.reflectType)
+	}
+
+	return fmt.Errorf(
+		"method %s on struct %s expected to have %s",
+		method.Name,
+		structType.Name(),
+		argsType,
+	)
+}
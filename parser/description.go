@@ -0,0 +1,65 @@
+package parser
+
+import (
+	"reflect"
+	"sync"
+)
+
+// TypeDescriptions holds descriptions of a type that are registered from
+// outside the type definition, usually by code generated with groot describe.
+type TypeDescriptions struct {
+	Description string
+	// Fields maps struct field names to their descriptions
+	Fields map[string]string
+	Values []ValueDescription
+}
+
+// ValueDescription is the description of a single enum value, matched by its
+// Go value
+type ValueDescription struct {
+	Value       interface{}
+	Description string
+}
+
+var registeredDescriptions = struct {
+	sync.RWMutex
+	types map[reflect.Type]TypeDescriptions
+}{types: map[reflect.Type]TypeDescriptions{}}
+
+// RegisterDescriptions registers descriptions for the given type. Descriptions
+// set with tags or methods on the type take precedence over registered ones.
+// Types parsed before their descriptions are registered won't include them.
+func RegisterDescriptions(t reflect.Type, descriptions TypeDescriptions) {
+	registeredDescriptions.Lock()
+	defer registeredDescriptions.Unlock()
+	registeredDescriptions.types[t] = descriptions
+}
+
+func getRegisteredDescriptions(t reflect.Type) TypeDescriptions {
+	registeredDescriptions.RLock()
+	defer registeredDescriptions.RUnlock()
+	return registeredDescriptions.types[t]
+}
+
+func getRegisteredTypeDescription(t reflect.Type) string {
+	return getRegisteredDescriptions(t).Description
+}
+
+func getRegisteredFieldDescription(t reflect.Type, fieldName string) string {
+	return getRegisteredDescriptions(t).Fields[fieldName]
+}
+
+func getRegisteredValueDescription(t reflect.Type, value interface{}) string {
+	for _, valueDescription := range getRegisteredDescriptions(t).Values {
+		goValue := reflect.ValueOf(valueDescription.Value)
+		if !goValue.IsValid() || !goValue.Type().ConvertibleTo(t) {
+			continue
+		}
+
+		if goValue.Convert(t).Interface() == value {
+			return valueDescription.Description
+		}
+	}
+
+	return ""
+}
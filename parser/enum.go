@@ -1,10 +1,16 @@
 package parser
 
-import "reflect"
+import (
+	"fmt"
+	"reflect"
+)
 
 type Enum struct {
 	reflectType reflect.Type
-	values      []string
+	name        string
+	description string
+	visibility  string
+	values      []EnumValue
 }
 
 func NewEnum(t reflect.Type) (*Enum, error) {
@@ -12,20 +18,93 @@ func NewEnum(t reflect.Type) (*Enum, error) {
 		panic(err)
 	}
 
-	values := reflect.New(t).
-		MethodByName("Values").
-		Call([]reflect.Value{})[0].
-		Interface().([]string)
+	values, err := getEnumValues(t)
+	if err != nil {
+		return nil, err
+	}
+
+	enum := &Enum{
+		reflectType: t,
+		name:        getTypeName(t),
+		description: getTypeDescription(t),
+		visibility:  getTypeVisibility(t),
+		values:      values,
+	}
 
-	enum := &Enum{t, values}
 	cache.set(t, enum)
 	return enum, nil
 }
 
-func (e *Enum) Values() []string {
+func (e *Enum) Name() string {
+	return e.name
+}
+
+func (e *Enum) Description() string {
+	return e.description
+}
+
+func (e *Enum) Visibility() string {
+	return e.visibility
+}
+
+func (e *Enum) Values() []EnumValue {
 	return e.values
 }
 
 func (e *Enum) ReflectType() reflect.Type {
 	return e.reflectType
 }
+
+func getEnumValues(t reflect.Type) ([]EnumValue, error) {
+	var (
+		values     = []EnumValue{}
+		seenNames  = map[string]bool{}
+		seenValues = map[interface{}]string{}
+		enumValue  = reflect.New(t).Interface()
+	)
+
+	if enumType, ok := enumValue.(EnumTypeWithValues); ok {
+		values = append(values, enumType.EnumValues()...)
+	} else {
+		for _, name := range enumValue.(EnumType).Values() {
+			values = append(values, EnumValue{Name: name, Value: name})
+		}
+	}
+
+	for i, value := range values {
+		goValue := reflect.ValueOf(value.Value)
+		if !goValue.IsValid() || !goValue.Type().ConvertibleTo(t) {
+			return nil, fmt.Errorf(
+				"value of enum value %s on enum %s should be convertible to %s, got %T",
+				value.Name,
+				t.Name(),
+				t,
+				value.Value,
+			)
+		}
+
+		values[i].Value = goValue.Convert(t).Interface()
+		if value.Description == "" {
+			values[i].Description = getRegisteredValueDescription(t, values[i].Value)
+		}
+
+		if seenNames[value.Name] {
+			return nil, fmt.Errorf("enum value %s defined more than once on enum %s", value.Name, t.Name())
+		}
+
+		if name, ok := seenValues[values[i].Value]; ok {
+			return nil, fmt.Errorf(
+				"enum values %s and %s on enum %s have the same value %v",
+				name,
+				value.Name,
+				t.Name(),
+				value.Value,
+			)
+		}
+
+		seenNames[value.Name] = true
+		seenValues[values[i].Value] = value.Name
+	}
+
+	return values, nil
+}
@@ -3,6 +3,7 @@ package parser
 import (
 	"fmt"
 	"reflect"
+	"time"
 )
 
 type Field struct {
@@ -12,66 +13,144 @@ type Field struct {
 	argsInput         *Input
 	resolver          *Resolver
 	subscriber        *Subscriber
+	transformer       *Transformer
 	jsonName          string
+	graphqlName       string
+	omitEmpty         bool
+	asString          bool
+	async             bool
+	visibility        string
+	timeout           time.Duration
+	hasTimeout        bool
+	cost              int
+	hasCost           bool
+	costMethod        *CostMethod
 	description       string
 	deprecationReason string
 }
 
 func NewField(t TypeWithFields, field reflect.StructField) (*Field, error) {
-	if field.Tag.Get("json") == "-" || !field.IsExported() {
+	if !field.IsExported() || isFieldIgnored(field) {
 		return nil, nil
 	}
 
 	var (
 		subscriber  *Subscriber
 		resolver    *Resolver
+		transformer *Transformer
 		argsInput   *Input
 		fieldType   Type
 		err         error
+		jsonTag     = parseJSONTag(field)
 		objectField = &Field{
 			structField:       field,
 			object:            t,
 			description:       field.Tag.Get("description"),
-			jsonName:          field.Tag.Get("json"),
+			jsonName:          jsonTag.name,
+			graphqlName:       field.Tag.Get("graphql"),
+			omitEmpty:         jsonTag.omitEmpty,
+			asString:          jsonTag.asString,
 			deprecationReason: field.Tag.Get("deprecate"),
+			visibility:        field.Tag.Get("visibility"),
 		}
 	)
 
-	fieldType, err = getOrCreateType(field.Type)
+	if objectField.async, err = parseBoolTag(t.ReflectType(), field, "async"); err != nil {
+		return nil, err
+	}
+
+	objectField.timeout, objectField.hasTimeout, err = parseDurationTag(t.ReflectType(), field, "timeout")
 	if err != nil {
 		return nil, err
 	}
 
-	objectField.type_ = fieldType
-	if err := validateFieldType(t.ReflectType(), field); err != nil {
+	objectField.cost, objectField.hasCost, err = parseIntTag(t.ReflectType(), field, "cost")
+	if err != nil {
+		return nil, err
+	}
+
+	// fields with the string option are exposed as strings
+	typeField := field
+	if jsonTag.asString {
+		typeField.Type = getStringifiedType(field.Type)
+	}
+
+	if err := validateFieldType(t.ReflectType(), typeField); err != nil {
+		return nil, err
+	}
+
+	fieldType, err = getOrCreateType(typeField.Type)
+	if err != nil {
 		return nil, err
 	}
 
+	objectField.type_ = fieldType
+
 	if t.ReflectType().Name() == "Subscription" {
 		if subscriber, err = NewResolver(objectField); err != nil {
 			return nil, err
 		}
 
-		if argsInput, err = getResolverArgsInput(subscriber); err != nil {
-			return nil, err
-		}
+		argsInput = subscriber.ArgsInput()
 	} else {
 		if resolver, err = NewResolver(objectField); err != nil {
 			return nil, err
 		}
 
 		if resolver != nil {
-			argsInput, err = getResolverArgsInput(resolver)
-			if err != nil {
+			argsInput = resolver.ArgsInput()
+		}
+
+		if transformer, err = NewTransformer(objectField); err != nil {
+			return nil, err
+		}
+
+		if transformer != nil && resolver != nil {
+			return nil, fmt.Errorf(
+				"field %s on struct %s cannot have both a resolver and a transformer",
+				field.Name,
+				t.ReflectType().Name(),
+			)
+		}
+
+		if transformer != nil {
+			argsInput = transformer.ArgsInput()
+
+			// the field is exposed as the type the transformer returns
+			if fieldType, err = getOrCreateType(transformer.reflectMethod.Type.Out(0)); err != nil {
 				return nil, err
 			}
 		}
 	}
 
+	// the resolver would be validated against the type of the struct field
+	// instead of String
+	if jsonTag.asString && (resolver != nil || subscriber != nil || transformer != nil) {
+		return nil, fmt.Errorf(
+			"field %s on struct %s cannot have both the string json option and a resolver",
+			field.Name,
+			t.ReflectType().Name(),
+		)
+	}
+
 	objectField.resolver = resolver
 	objectField.subscriber = subscriber
+	objectField.transformer = transformer
 	objectField.argsInput = argsInput
 	objectField.type_ = fieldType
+
+	if objectField.costMethod, err = NewCostMethod(objectField); err != nil {
+		return nil, err
+	}
+
+	if objectField.costMethod != nil && objectField.hasCost {
+		return nil, fmt.Errorf(
+			"field %s on struct %s cannot have both the cost tag and a cost method",
+			field.Name,
+			t.ReflectType().Name(),
+		)
+	}
+
 	return objectField, nil
 }
 
@@ -91,6 +170,10 @@ func (f *Field) Subscriber() *Subscriber {
 	return f.subscriber
 }
 
+func (f *Field) Transformer() *Transformer {
+	return f.transformer
+}
+
 func (f *Field) Type() Type {
 	return f.type_
 }
@@ -107,6 +190,56 @@ func (f *Field) JSONName() string {
 	return f.jsonName
 }
 
+// OmitEmpty reports whether the json tag of the field has the omitempty option
+func (f *Field) OmitEmpty() bool {
+	return f.omitEmpty
+}
+
+// AsString reports whether the json tag of the field has the string option,
+// in which case the field is exposed as a String
+func (f *Field) AsString() bool {
+	return f.asString
+}
+
+// Async reports whether the async tag of the field is set, in which case its
+// resolver is run in a goroutine
+func (f *Field) Async() bool {
+	return f.async
+}
+
+// Visibility returns the visibility set with the visibility tag, which drops
+// the field from schemas which don't include the visibility
+func (f *Field) Visibility() string {
+	return f.visibility
+}
+
+// Cost returns the cost set with the cost tag of the field, and whether the
+// tag is set
+func (f *Field) Cost() (int, bool) {
+	return f.cost, f.hasCost
+}
+
+// CostMethod returns the Cost<Field> method of the field, if it's defined
+func (f *Field) CostMethod() *CostMethod {
+	return f.costMethod
+}
+
+// Timeout returns the duration set with the timeout tag of the field, and
+// whether the tag is set. A timeout of zero means the field has no timeout.
+func (f *Field) Timeout() (time.Duration, bool) {
+	return f.timeout, f.hasTimeout
+}
+
+// TagName returns the name set with the graphql tag, falling back to the
+// json tag. An empty string is returned if neither is set.
+func (f *Field) TagName() string {
+	if f.graphqlName != "" {
+		return f.graphqlName
+	}
+
+	return f.jsonName
+}
+
 func (f *Field) StructField() reflect.StructField {
 	return f.structField
 }
@@ -115,6 +248,17 @@ func (f *Field) DeprecationReason() string {
 	return f.deprecationReason
 }
 
+// isFieldIgnored reports whether a field is excluded from the schema with the
+// graphql tag set to "-", or the json tag set to "-" and no graphql tag
+func isFieldIgnored(field reflect.StructField) bool {
+	graphqlName := field.Tag.Get("graphql")
+	if graphqlName != "" {
+		return graphqlName == "-"
+	}
+
+	return field.Tag.Get("json") == "-"
+}
+
 func validateFieldType(structType reflect.Type, field reflect.StructField) error {
 	parserType, err := getTypeKind(field.Type)
 	if err != nil {
@@ -12,7 +12,44 @@ type EnumType interface {
 	Values() []string
 }
 
+// EnumValue describes a single value of an enum. Value holds the Go value the
+// GraphQL name maps to and must be convertible to the enum's Go type.
+type EnumValue struct {
+	Name        string
+	Value       interface{}
+	Description string
+	Deprecated  string
+	// Visibility drops the value from schemas which don't include the
+	// visibility
+	Visibility string
+}
+
+// EnumTypeWithValues can be implemented instead of EnumType to document and
+// deprecate enum values, or to map them to non string Go values.
+type EnumTypeWithValues interface {
+	EnumValues() []EnumValue
+}
+
 type ScalarType interface {
 	json.Marshaler
 	json.Unmarshaler
 }
+
+type TypeWithName interface {
+	GraphQLName() string
+}
+
+type TypeWithDescription interface {
+	GraphQLDescription() string
+}
+
+type ScalarTypeWithSpecifiedBy interface {
+	ScalarType
+	GraphQLSpecifiedBy() string
+}
+
+// TypeWithVisibility can be implemented to drop a type, along with the fields
+// and arguments of that type, from schemas which don't include the visibility
+type TypeWithVisibility interface {
+	GraphQLVisibility() string
+}
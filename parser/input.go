@@ -6,6 +6,9 @@ import (
 
 type Input struct {
 	reflectType reflect.Type
+	name        string
+	description string
+	visibility  string
 	validator   *InputValidator
 	arguments   []*Argument
 }
@@ -17,6 +20,9 @@ func NewInput(t reflect.Type) (*Input, error) {
 
 	input := &Input{
 		reflectType: t,
+		name:        getTypeName(t),
+		description: getTypeDescription(t),
+		visibility:  getTypeVisibility(t),
 		arguments:   []*Argument{},
 	}
 
@@ -45,6 +51,18 @@ func (i *Input) Arguments() []*Argument {
 	return i.arguments
 }
 
+func (i *Input) Name() string {
+	return i.name
+}
+
+func (i *Input) Description() string {
+	return i.description
+}
+
+func (i *Input) Visibility() string {
+	return i.visibility
+}
+
 func (i *Input) Validator() *InputValidator {
 	return i.validator
 }
@@ -74,7 +92,13 @@ func getArguments(t *Input, reflectType reflect.Type) ([]*Argument, error) {
 			return nil, err
 		}
 
-		args = append(args, arg)
+		if arg != nil {
+			if arg.description == "" {
+				arg.description = getRegisteredFieldDescription(reflectType, field.Name)
+			}
+
+			args = append(args, arg)
+		}
 	}
 
 	return args, nil
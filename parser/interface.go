@@ -7,6 +7,9 @@ import (
 
 type Interface struct {
 	reflectType reflect.Type
+	name        string
+	description string
+	visibility  string
 	fields      []*Field
 }
 
@@ -20,13 +23,19 @@ func NewInterface(t reflect.Type) (*Interface, error) {
 	}
 
 	interfaceDefReflectType := t.Method(0).Type.Out(0)
-	interface_, err := getOrCreateType(interfaceDefReflectType)
+	parserType, err := getOrCreateType(interfaceDefReflectType)
 	if err != nil {
 		return nil, err
 	}
 
+	// the Go interface is usually the one documented instead of the definition
+	interface_ := parserType.(*Interface)
+	if interface_.description == "" {
+		interface_.description = getRegisteredTypeDescription(t)
+	}
+
 	cache.set(t, interface_)
-	return interface_.(*Interface), nil
+	return interface_, nil
 }
 
 func NewInterfaceFromDefinition(t reflect.Type) (*Interface, error) {
@@ -36,6 +45,9 @@ func NewInterfaceFromDefinition(t reflect.Type) (*Interface, error) {
 
 	interface_ := &Interface{
 		reflectType: t,
+		name:        getMarkerName(t, reflect.TypeOf(InterfaceType{}), "Definition"),
+		description: getMarkerDescription(t, reflect.TypeOf(InterfaceType{})),
+		visibility:  getMarkerTag(t, reflect.TypeOf(InterfaceType{}), "visibility"),
 	}
 
 	cache.set(t, interface_)
@@ -50,9 +62,15 @@ func NewInterfaceFromDefinition(t reflect.Type) (*Interface, error) {
 }
 
 func (i *Interface) Name() string {
-	name := i.reflectType.Name()
-	name = name[:len(name)-len("Definition")]
-	return name
+	return i.name
+}
+
+func (i *Interface) Description() string {
+	return i.description
+}
+
+func (i *Interface) Visibility() string {
+	return i.visibility
 }
 
 func (i *Interface) Fields() []*Field {
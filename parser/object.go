@@ -4,6 +4,9 @@ import "reflect"
 
 type Object struct {
 	reflectType reflect.Type
+	name        string
+	description string
+	visibility  string
 	fields      []*Field
 	interfaces  []*Interface
 }
@@ -11,6 +14,9 @@ type Object struct {
 func NewObject(t reflect.Type) (*Object, error) {
 	object := &Object{
 		reflectType: t,
+		name:        getTypeName(t),
+		description: getTypeDescription(t),
+		visibility:  getTypeVisibility(t),
 		fields:      []*Field{},
 		interfaces:  []*Interface{},
 	}
@@ -37,6 +43,18 @@ func NewObject(t reflect.Type) (*Object, error) {
 	return object, nil
 }
 
+func (o *Object) Name() string {
+	return o.name
+}
+
+func (o *Object) Description() string {
+	return o.description
+}
+
+func (o *Object) Visibility() string {
+	return o.visibility
+}
+
 func (o *Object) Fields() []*Field {
 	return o.fields
 }
@@ -71,6 +89,10 @@ func getFields(t TypeWithFields, reflectType reflect.Type) ([]*Field, error) {
 		}
 
 		if objectField != nil {
+			if objectField.description == "" {
+				objectField.description = getRegisteredFieldDescription(reflectType, field.Name)
+			}
+
 			fields = append(fields, objectField)
 		}
 	}
@@ -47,6 +47,7 @@ var (
 	ErrNotInterface   = fmt.Errorf("type is not interface")
 	ErrNotScalar      = fmt.Errorf("type is not scalar")
 	ErrNotEnum        = fmt.Errorf("type is not enum")
+	ErrNotResolverSet = fmt.Errorf("value is not a struct or pointer to a struct")
 )
 
 func ParseObject(t reflect.Type) (*Object, error) {
@@ -114,6 +115,14 @@ func ParseScalar(t reflect.Type) (*Scalar, error) {
 	return nil, ErrNotScalar
 }
 
+func ParseResolvers(v reflect.Value) (*ResolverSet, error) {
+	if kind := v.Kind(); kind != reflect.Struct && !(kind == reflect.Ptr && v.Elem().Kind() == reflect.Struct) {
+		return nil, ErrNotResolverSet
+	}
+
+	return NewResolverSet(v)
+}
+
 func ParseEnum(t reflect.Type) (*Enum, error) {
 	grootType, err := getOrCreateType(t)
 	if err != nil {
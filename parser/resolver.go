@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"strings"
 
 	"github.com/graphql-go/graphql"
 )
@@ -20,12 +21,21 @@ const (
 	ResolverArgInput
 	ResolverArgContext
 	ResolverArgInfo
+	ResolverArgFieldValue
+	ResolverArgObject
+	// ResolverArgDependency is a parameter provided by the container of the
+	// schema or the request context
+	ResolverArgDependency
 )
 
 type Resolver struct {
 	reflectMethod reflect.Method
 	field         *Field
 	signature     []ResolverArgType
+	argsInput     *Input
+	// receiver is only valid for resolvers defined on a resolver struct, for
+	// other resolvers the receiver is the object being resolved
+	receiver reflect.Value
 }
 
 func NewResolver(field *Field) (*Resolver, error) {
@@ -41,7 +51,7 @@ func NewResolver(field *Field) (*Resolver, error) {
 		methodName = fmt.Sprintf("Resolve%s", fieldName)
 	}
 
-	method, hasMethod := object.MethodByName(methodName)
+	method, hasMethod := getMethod(object, methodName)
 
 	if object.Name() == "Subscription" {
 		if !hasMethod {
@@ -63,17 +73,36 @@ func NewResolver(field *Field) (*Resolver, error) {
 		return nil, nil
 	}
 
-	return &Resolver{
+	resolver := &Resolver{
 		reflectMethod: method,
 		field:         field,
-		signature:     getResolverArgumentSignature(method),
-	}, nil
+		signature:     getResolverArgumentSignature(method, 1),
+	}
+
+	argsInput, err := getResolverArgsInput(resolver)
+	if err != nil {
+		return nil, err
+	}
+
+	resolver.argsInput = argsInput
+	return resolver, nil
 }
 
 func (r *Resolver) ArgsSignature() []ResolverArgType {
 	return r.signature
 }
 
+// ArgsInput returns the input type of the arguments the resolver accepts
+func (r *Resolver) ArgsInput() *Input {
+	return r.argsInput
+}
+
+// Receiver returns the resolver struct the resolver is defined on, or an
+// invalid reflect.Value if it's defined on the object being resolved
+func (r *Resolver) Receiver() reflect.Value {
+	return r.receiver
+}
+
 func (r *Resolver) Field() *Field {
 	return r.field
 }
@@ -86,7 +115,36 @@ func (r *Resolver) ReflectMethod() reflect.Method {
 	return r.reflectMethod
 }
 
-func getResolverArgumentSignature(method reflect.Method) []ResolverArgType {
+// getMethod returns the method with the given name defined on either the type
+// or the pointer to the type
+func getMethod(t reflect.Type, name string) (reflect.Method, bool) {
+	if method, ok := t.MethodByName(name); ok {
+		return method, true
+	}
+
+	if t.Kind() == reflect.Ptr {
+		return reflect.Method{}, false
+	}
+
+	return reflect.PtrTo(t).MethodByName(name)
+}
+
+// getReceiverType returns the type of the receiver of a method, without the
+// pointer for methods defined on pointer receivers
+func getReceiverType(method reflect.Method) reflect.Type {
+	receiver := method.Func.Type().In(0)
+	if receiver.Kind() == reflect.Ptr {
+		return receiver.Elem()
+	}
+
+	return receiver
+}
+
+// getResolverArgumentSignature returns the types of the arguments of a
+// resolver method, starting from the argument at index start. Only a struct
+// at index start is the arguments of the field, so structs anywhere else are
+// dependencies like any other type.
+func getResolverArgumentSignature(method reflect.Method, start int) []ResolverArgType {
 	// method doesn't exis
 	if method.Type == nil {
 		return []ResolverArgType{}
@@ -99,17 +157,17 @@ func getResolverArgumentSignature(method reflect.Method) []ResolverArgType {
 		funcType         = method.Func.Type()
 	)
 
-	// start from 1 to ignore receiver
-	for i := 1; i < method.Func.Type().NumIn(); i++ {
+	// index 0 is the receiver
+	for i := start; i < method.Func.Type().NumIn(); i++ {
 		arg := funcType.In(i)
 		if arg.Implements(contextInterface) {
 			arguments = append(arguments, ResolverArgContext)
 		} else if arg == resolverInfoType {
 			arguments = append(arguments, ResolverArgInfo)
-		} else if arg.Kind() == reflect.Struct {
+		} else if arg.Kind() == reflect.Struct && i == start {
 			arguments = append(arguments, ResolverArgInput)
 		} else {
-			arguments = append(arguments, ResolverArgOther)
+			arguments = append(arguments, ResolverArgDependency)
 		}
 	}
 
@@ -117,115 +175,133 @@ func getResolverArgumentSignature(method reflect.Method) []ResolverArgType {
 }
 
 func validateResolverArguments(method reflect.Method) error {
+	return validateResolverArgumentSignature(method, getResolverArgumentSignature(method, 1))
+}
+
+// validateResolverArgumentSignature validates the arguments of a resolver
+// apart from the receiver and any arguments that precede signature
+func validateResolverArgumentSignature(method reflect.Method, signature []ResolverArgType) error {
 	var (
-		funcType = method.Func.Type()
-		inCount  = funcType.NumIn()
-
-		structType          = funcType.In(0)
-		argsSignature       = getResolverArgumentSignature(method)
-		validArgPermuations = [][]ResolverArgType{
-			{ResolverArgInput, ResolverArgContext, ResolverArgInfo},
-			{ResolverArgInput, ResolverArgContext},
-			{ResolverArgInput, ResolverArgInfo},
-			{ResolverArgContext, ResolverArgInfo},
-			{ResolverArgInput},
-			{ResolverArgContext},
-			{ResolverArgInfo},
-			{},
-		}
+		structType = getReceiverType(method)
+		accepted   = map[ResolverArgType]bool{}
 	)
 
-	if inCount > 4 {
-		return fmt.Errorf(
-			"resolver %s on struct %s can accept only up to 3 arguments of type (Args, context.Context, graphql.ResolveInfo)",
-			method.Name,
-			structType.Name(),
-		)
-	}
+	for _, arg := range signature {
+		// dependencies can be accepted anywhere in the signature
+		if arg == ResolverArgDependency {
+			continue
+		}
 
-	isValid := false
-	for _, permutation := range validArgPermuations {
-		if reflect.DeepEqual(argsSignature, permutation) {
-			isValid = true
-			break
+		if accepted[arg] {
+			return fmt.Errorf(
+				"resolver %s on struct %s can accept each of (context.Context, graphql.ResolveInfo) only once, in any order after the args",
+				method.Name,
+				structType.Name(),
+			)
 		}
-	}
 
-	if !isValid {
-		return fmt.Errorf(
-			"resolver %s on struct %s can accept either no arguments or one of the following permutations: \n"+
-				"(args, context, info)\n"+
-				"(args, context)\n"+
-				"(args, info)\n"+
-				"(context, info)\n"+
-				"(args)\n"+
-				"(context)\n"+
-				"(info)",
-			method.Name,
-			structType.Name(),
-		)
+		accepted[arg] = true
 	}
 
 	return nil
 }
 
-func validateResolverOutput(method reflect.Method, returnType reflect.Type) error {
+// getResolverOutputType returns T for functions returning either T, (T, error)
+// or (T, []error)
+func getResolverOutputType(funcType reflect.Type) (reflect.Type, bool) {
 	var (
-		funcType       = method.Func.Type()
 		errorInterface = reflect.TypeOf((*error)(nil)).Elem()
-		outCount       = funcType.NumOut()
-		structType     = funcType.In(0)
+		errorSliceType = reflect.TypeOf([]error{})
 	)
 
-	err := func() error {
-		returnMsg := ""
-		for i := 0; i < outCount; i++ {
-			returnMsg += funcType.Out(i).String() + ", "
+	switch funcType.NumOut() {
+	case 1:
+		return funcType.Out(0), true
+	case 2:
+		if errType := funcType.Out(1); errType.Implements(errorInterface) || errType == errorSliceType {
+			return funcType.Out(0), true
 		}
-		returnMsg = returnMsg[:len(returnMsg)-2]
+	}
 
-		return fmt.Errorf(
-			"one of the below return types was expect for resolver %s on struct %s, got (%s)\n"+
-				"(%s, error)\n"+
-				"(func() (%s, error), error)",
-			method.Name,
-			structType.Name(),
-			returnMsg,
-			returnType,
-			returnType,
-		)
-	}()
+	return nil, false
+}
 
-	if outCount != 2 || !method.Type.Out(1).Implements(errorInterface) {
-		return err
+func validateResolverOutput(method reflect.Method, returnType reflect.Type) error {
+	var (
+		funcType   = method.Func.Type()
+		outCount   = funcType.NumOut()
+		structType = getReceiverType(method)
+		returnMsg  = []string{}
+	)
+
+	for i := 0; i < outCount; i++ {
+		returnMsg = append(returnMsg, funcType.Out(i).String())
 	}
 
-	actualReturnType := method.Type.Out(0)
+	err := fmt.Errorf(
+		"one of the below return types was expect for resolver %s on struct %s, got (%s)\n"+
+			"%s\n"+
+			"(%s, error)\n"+
+			"(%s, []error)\n"+
+			"(func() (%s, error), error)",
+		method.Name,
+		structType.Name(),
+		strings.Join(returnMsg, ", "),
+		returnType,
+		returnType,
+		returnType,
+		returnType,
+	)
 
-	if actualReturnType.Kind() == reflect.Func {
-		returnMethod := method
-		returnMethod.Type = actualReturnType
+	actualReturnType, ok := getResolverOutputType(funcType)
+	if !ok {
+		return err
+	}
 
-		if subErr := validateFieldSubscriber(returnMethod, returnType); subErr != nil {
+	if actualReturnType.Kind() == reflect.Func {
+		thunkReturnType, ok := getResolverOutputType(actualReturnType)
+		if !ok || actualReturnType.NumIn() != 0 || !isCompatibleOutputType(thunkReturnType, returnType) {
 			return err
 		}
 
 		return nil
 	}
 
-	if actualReturnType != returnType {
+	if !isCompatibleOutputType(actualReturnType, returnType) {
 		return err
 	}
 
 	return nil
 }
 
+// isCompatibleOutputType returns whether a value of type t returned by a
+// resolver can be adapted to a field of type fieldType, which is the case when
+// t is assignable to fieldType, is the element of pointer type fieldType,
+// can be converted to fieldType without changing its kind, or is a slice with
+// elements compatible with the elements of slice type fieldType
+func isCompatibleOutputType(t, fieldType reflect.Type) bool {
+	switch {
+	case t == fieldType:
+		return true
+	case t.AssignableTo(fieldType):
+		return true
+	case t.Kind() == fieldType.Kind() && t.ConvertibleTo(fieldType) && t.Kind() != reflect.Slice:
+		return true
+	case fieldType.Kind() == reflect.Ptr && t.Kind() != reflect.Ptr:
+		return isCompatibleOutputType(t, fieldType.Elem())
+	case fieldType.Kind() == reflect.Slice && t.Kind() == reflect.Slice:
+		return isCompatibleOutputType(t.Elem(), fieldType.Elem())
+	}
+
+	return false
+}
+
 func validateSubscriberOutput(method reflect.Method, returnType reflect.Type) error {
 	var (
 		funcType       = method.Func.Type()
 		errorInterface = reflect.TypeOf((*error)(nil)).Elem()
 		outCount       = funcType.NumOut()
-		structType     = funcType.In(0)
+		structType     = getReceiverType(method)
 	)
 
 	if outCount != 2 {
@@ -277,12 +353,20 @@ func validateFieldSubscriber(method reflect.Method, returnType reflect.Type) err
 }
 
 func getResolverArgsInput(resolver *Resolver) (*Input, error) {
-	signature := resolver.ArgsSignature()
-	if len(signature) == 0 || signature[0] != ResolverArgInput {
+	argsIndex := -1
+	for i, arg := range resolver.ArgsSignature() {
+		if arg == ResolverArgInput {
+			argsIndex = i
+			break
+		}
+	}
+
+	if argsIndex == -1 {
 		return nil, nil
 	}
 
-	reflectType := resolver.reflectMethod.Type.In(1)
+	// skip the receiver
+	reflectType := resolver.reflectMethod.Type.In(argsIndex + 1)
 
 	// this input type will not be created in the schema
 	input, err := getOrCreateArgumentType(reflectType)
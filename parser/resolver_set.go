@@ -0,0 +1,160 @@
+package parser
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ResolverSet is a struct with Resolve<Field> methods resolving the fields of
+// an object, which receive the object as their first argument. It allows
+// resolvers to depend on values like database handles without globals.
+type ResolverSet struct {
+	reflectValue reflect.Value
+	object       *Object
+	resolvers    []*Resolver
+}
+
+func NewResolverSet(v reflect.Value) (*ResolverSet, error) {
+	// use a pointer so methods on both value and pointer receivers are found
+	if v.Kind() != reflect.Ptr {
+		ptr := reflect.New(v.Type())
+		ptr.Elem().Set(v)
+		v = ptr
+	}
+
+	var (
+		t           = v.Type()
+		objectType  reflect.Type
+		methodNames = []string{}
+	)
+
+	for i := 0; i < t.NumMethod(); i++ {
+		method := t.Method(i)
+		if !strings.HasPrefix(method.Name, "Resolve") {
+			continue
+		}
+
+		if method.Type.NumIn() < 2 {
+			return nil, fmt.Errorf(
+				"resolver %s on struct %s should accept the object it resolves as its first argument",
+				method.Name,
+				getReceiverType(method).Name(),
+			)
+		}
+
+		paramType := method.Type.In(1)
+		if paramType.Kind() == reflect.Ptr {
+			paramType = paramType.Elem()
+		}
+
+		if objectType != nil && objectType != paramType {
+			return nil, fmt.Errorf(
+				"resolvers on struct %s should all resolve the same object, got %s and %s",
+				getReceiverType(method).Name(),
+				objectType.Name(),
+				paramType.Name(),
+			)
+		}
+
+		objectType = paramType
+		methodNames = append(methodNames, method.Name)
+	}
+
+	if objectType == nil {
+		return nil, fmt.Errorf("struct %s doesn't have any resolvers", t.Elem().Name())
+	}
+
+	object, err := ParseObject(objectType)
+	if err != nil {
+		return nil, err
+	}
+
+	set := &ResolverSet{
+		reflectValue: v,
+		object:       object,
+		resolvers:    []*Resolver{},
+	}
+
+	for _, methodName := range methodNames {
+		resolver, err := newResolverSetResolver(set, methodName)
+		if err != nil {
+			return nil, err
+		}
+
+		set.resolvers = append(set.resolvers, resolver)
+	}
+
+	return set, nil
+}
+
+// Object returns the object the resolvers resolve fields of
+func (s *ResolverSet) Object() *Object {
+	return s.object
+}
+
+func (s *ResolverSet) Resolvers() []*Resolver {
+	return s.resolvers
+}
+
+func (s *ResolverSet) ReflectValue() reflect.Value {
+	return s.reflectValue
+}
+
+func newResolverSetResolver(set *ResolverSet, methodName string) (*Resolver, error) {
+	var (
+		field     *Field
+		method, _ = set.reflectValue.Type().MethodByName(methodName)
+		fieldName = strings.TrimPrefix(methodName, "Resolve")
+		setName   = getReceiverType(method).Name()
+	)
+
+	for _, objectField := range set.object.Fields() {
+		if objectField.structField.Name == fieldName {
+			field = objectField
+		}
+	}
+
+	if field == nil {
+		return nil, fmt.Errorf(
+			"resolver %s on struct %s doesn't match any field on struct %s",
+			methodName,
+			setName,
+			set.object.ReflectType().Name(),
+		)
+	}
+
+	if field.Resolver() != nil || field.Transformer() != nil {
+		return nil, fmt.Errorf(
+			"field %s on struct %s already has a resolver, and cannot be resolved by %s on struct %s",
+			fieldName,
+			set.object.ReflectType().Name(),
+			methodName,
+			setName,
+		)
+	}
+
+	signature := getResolverArgumentSignature(method, 2)
+	if err := validateResolverArgumentSignature(method, signature); err != nil {
+		return nil, err
+	}
+
+	if err := validateResolverOutput(method, field.structField.Type); err != nil {
+		return nil, err
+	}
+
+	resolver := &Resolver{
+		reflectMethod: method,
+		field:         field,
+		signature:     append([]ResolverArgType{ResolverArgObject}, signature...),
+		receiver:      set.reflectValue,
+	}
+
+	argsInput, err := getResolverArgsInput(resolver)
+	if err != nil {
+		return nil, err
+	}
+
+	resolver.argsInput = argsInput
+	return resolver, nil
+}
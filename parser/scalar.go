@@ -11,6 +11,10 @@ const (
 
 type Scalar struct {
 	reflectType reflect.Type
+	name        string
+	description string
+	visibility  string
+	specifiedBy string
 }
 
 func NewScalar(t reflect.Type) (*Scalar, error) {
@@ -18,11 +22,39 @@ func NewScalar(t reflect.Type) (*Scalar, error) {
 		panic(err)
 	}
 
-	scalar := &Scalar{t}
+	scalar := &Scalar{
+		reflectType: t,
+		name:        getTypeName(t),
+		description: getTypeDescription(t),
+		visibility:  getTypeVisibility(t),
+	}
+
+	if scalarType, ok := reflect.New(t).Interface().(ScalarTypeWithSpecifiedBy); ok {
+		scalar.specifiedBy = scalarType.GraphQLSpecifiedBy()
+	}
+
 	cache.set(t, scalar)
 	return scalar, nil
 }
 
-func (s Scalar) ReflectType() reflect.Type {
+func (s *Scalar) Name() string {
+	return s.name
+}
+
+func (s *Scalar) Description() string {
+	return s.description
+}
+
+func (s *Scalar) Visibility() string {
+	return s.visibility
+}
+
+// SpecifiedBy returns the URL of the specification the scalar conforms to, set
+// with GraphQLSpecifiedBy
+func (s *Scalar) SpecifiedBy() string {
+	return s.specifiedBy
+}
+
+func (s *Scalar) ReflectType() reflect.Type {
 	return s.reflectType
 }
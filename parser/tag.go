@@ -0,0 +1,140 @@
+package parser
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type jsonTag struct {
+	name      string
+	omitEmpty bool
+	asString  bool
+}
+
+// parseJSONTag parses the json tag of a struct field the same way
+// encoding/json does, splitting the name from options like omitempty
+func parseJSONTag(field reflect.StructField) jsonTag {
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return jsonTag{name: tag}
+	}
+
+	parts := strings.Split(tag, ",")
+	parsed := jsonTag{name: parts[0]}
+
+	for _, option := range parts[1:] {
+		switch option {
+		case "omitempty":
+			parsed.omitEmpty = true
+		case "string":
+			parsed.asString = isStringifiable(field.Type)
+		}
+	}
+
+	return parsed
+}
+
+// isStringifiable reports whether encoding/json applies the string option to
+// a field of the given type, which is only the case for numbers and booleans.
+// Strings are left untouched since they're already exposed as strings.
+func isStringifiable(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	// int64 and the likes aren't supported otherwise, so an error is fine
+	if kind, err := getTypeKind(t); err == nil && kind != KindScalar {
+		return false
+	}
+
+	switch t.Kind() {
+	case
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64,
+		reflect.Bool:
+		return true
+	}
+
+	return false
+}
+
+// getStringifiedType returns the type a field with the string option is
+// exposed as, which is string, or *string if the field is a pointer
+func getStringifiedType(t reflect.Type) reflect.Type {
+	stringType := reflect.TypeOf("")
+	if t.Kind() == reflect.Ptr {
+		return reflect.PtrTo(stringType)
+	}
+
+	return stringType
+}
+
+// parseBoolTag parses a tag with a boolean value, which is false if the tag
+// isn't set
+func parseBoolTag(structType reflect.Type, field reflect.StructField, key string) (bool, error) {
+	tag, ok := field.Tag.Lookup(key)
+	if !ok {
+		return false, nil
+	}
+
+	value, err := strconv.ParseBool(tag)
+	if err != nil {
+		return false, fmt.Errorf(
+			"invalid value %q for tag %s of field %s on struct %s, expected a boolean",
+			tag,
+			key,
+			field.Name,
+			structType.Name(),
+		)
+	}
+
+	return value, nil
+}
+
+// parseDurationTag parses a tag with a duration value like "200ms", and
+// reports whether the tag is set
+func parseDurationTag(structType reflect.Type, field reflect.StructField, key string) (time.Duration, bool, error) {
+	tag, ok := field.Tag.Lookup(key)
+	if !ok {
+		return 0, false, nil
+	}
+
+	value, err := time.ParseDuration(tag)
+	if err != nil || value < 0 {
+		return 0, false, fmt.Errorf(
+			"invalid value %q for tag %s of field %s on struct %s, expected a duration like \"200ms\"",
+			tag,
+			key,
+			field.Name,
+			structType.Name(),
+		)
+	}
+
+	return value, true, nil
+}
+
+// parseIntTag parses a tag with an integer value, and reports whether the tag
+// is set
+func parseIntTag(structType reflect.Type, field reflect.StructField, key string) (int, bool, error) {
+	tag, ok := field.Tag.Lookup(key)
+	if !ok {
+		return 0, false, nil
+	}
+
+	value, err := strconv.Atoi(tag)
+	if err != nil || value < 0 {
+		return 0, false, fmt.Errorf(
+			"invalid value %q for tag %s of field %s on struct %s, expected a positive integer",
+			tag,
+			key,
+			field.Name,
+			structType.Name(),
+		)
+	}
+
+	return value, true, nil
+}
@@ -0,0 +1,87 @@
+package parser
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Transformer is a method named Transform<Field> that receives the value of a
+// struct field along with the field's arguments, which allows fields resolved
+// from the struct to accept arguments without a resolver
+type Transformer = Resolver
+
+func NewTransformer(field *Field) (*Transformer, error) {
+	var (
+		fieldName  = field.structField.Name
+		methodName = fmt.Sprintf("Transform%s", fieldName)
+		object     = field.Object().ReflectType()
+	)
+
+	method, hasMethod := getMethod(object, methodName)
+	if !hasMethod {
+		return nil, nil
+	}
+
+	if err := validateFieldTransformer(method, field.structField.Type); err != nil {
+		return nil, err
+	}
+
+	signature := append(
+		[]ResolverArgType{ResolverArgFieldValue},
+		getResolverArgumentSignature(method, 2)...,
+	)
+
+	transformer := &Transformer{
+		reflectMethod: method,
+		field:         field,
+		signature:     signature,
+	}
+
+	argsInput, err := getResolverArgsInput(transformer)
+	if err != nil {
+		return nil, err
+	}
+
+	transformer.argsInput = argsInput
+	return transformer, nil
+}
+
+func validateFieldTransformer(method reflect.Method, valueType reflect.Type) error {
+	var (
+		funcType   = method.Type
+		structType = getReceiverType(method)
+	)
+
+	if funcType.NumIn() < 2 || funcType.In(1) != valueType {
+		return fmt.Errorf(
+			"transformer %s on struct %s should accept the value of the field of type %s as its first argument",
+			method.Name,
+			structType.Name(),
+			valueType,
+		)
+	}
+
+	if err := validateResolverArgumentSignature(method, getResolverArgumentSignature(method, 2)); err != nil {
+		return err
+	}
+
+	returnType, ok := getResolverOutputType(funcType)
+	if !ok {
+		return fmt.Errorf(
+			"return type of T, (T, error) or (T, []error) was expected for transformer %s on struct %s",
+			method.Name,
+			structType.Name(),
+		)
+	}
+
+	if kind, err := getTypeKind(returnType); err != nil || kind == KindInterfaceDefinition {
+		return fmt.Errorf(
+			"return type %s of transformer %s on struct %s is not supported",
+			returnType,
+			method.Name,
+			structType.Name(),
+		)
+	}
+
+	return nil
+}
@@ -3,6 +3,9 @@ package parser
 import (
 	"fmt"
 	"reflect"
+	"regexp"
+	"runtime"
+	"strings"
 )
 
 type Kind int
@@ -121,12 +124,132 @@ func isInterfaceDefinition(t reflect.Type) bool {
 	return false
 }
 
-func getTypeKind(t reflect.Type) (Kind, error) {
+func isTypeEnum(t reflect.Type) bool {
 	var (
-		enumType   = reflect.TypeOf((*EnumType)(nil)).Elem()
-		scalarType = reflect.TypeOf((*ScalarType)(nil)).Elem()
+		enumType           = reflect.TypeOf((*EnumType)(nil)).Elem()
+		enumWithValuesType = reflect.TypeOf((*EnumTypeWithValues)(nil)).Elem()
 	)
 
+	switch t.Kind() {
+	case reflect.String:
+		if t.Name() == "string" {
+			return false
+		}
+
+		return t.Implements(enumType) || reflect.PtrTo(t).Implements(enumWithValuesType)
+
+	case
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Uint, reflect.Uint8, reflect.Uint16:
+		return reflect.PtrTo(t).Implements(enumWithValuesType)
+	}
+
+	return false
+}
+
+var (
+	typeNamePackageRegex = regexp.MustCompile(`[\w./-]*\.`)
+	typeNameInvalidRegex = regexp.MustCompile(`[^_0-9A-Za-z]`)
+)
+
+// getTypeName returns the GraphQL name of a type that implements TypeWithName,
+// either on the type itself or on the pointer to it, falling back to the name
+// of the Go type
+func getTypeName(t reflect.Type) string {
+	typeWithName, ok := reflect.New(t).Interface().(TypeWithName)
+	if ok && isMethodDeclared(t, "GraphQLName") {
+		return typeWithName.GraphQLName()
+	}
+
+	return getDefaultTypeName(t)
+}
+
+// isMethodDeclared reports whether a method is declared on a type or the
+// pointer to it, rather than promoted from an embedded type, which would give
+// every type embedding another the name of the embedded type
+func isMethodDeclared(t reflect.Type, name string) bool {
+	// methods are looked up on the type first, since the methods of the type
+	// are promoted to the pointer as well
+	for _, receiver := range []reflect.Type{t, reflect.PtrTo(t)} {
+		method, ok := receiver.MethodByName(name)
+		if !ok {
+			continue
+		}
+
+		// promoted methods are wrappers generated by the compiler
+		fn := runtime.FuncForPC(method.Func.Pointer())
+		file, _ := fn.FileLine(fn.Entry())
+		return file != "<autogenerated>"
+	}
+
+	return false
+}
+
+// getDefaultTypeName returns the name of the Go type with package paths and
+// brackets stripped from the names of generic types, so
+// Page[example.com/models.User] becomes PageUser
+func getDefaultTypeName(t reflect.Type) string {
+	name := typeNamePackageRegex.ReplaceAllString(t.Name(), "")
+	return typeNameInvalidRegex.ReplaceAllString(name, "")
+}
+
+// getTypeDescription returns the description of a type that implements
+// TypeWithDescription, either on the type itself or on the pointer to it,
+// falling back to the registered description of the type
+func getTypeDescription(t reflect.Type) string {
+	if typeWithDescription, ok := reflect.New(t).Interface().(TypeWithDescription); ok {
+		return typeWithDescription.GraphQLDescription()
+	}
+
+	return getRegisteredTypeDescription(t)
+}
+
+// getTypeVisibility returns the visibility of a type that implements
+// TypeWithVisibility
+func getTypeVisibility(t reflect.Type) string {
+	if typeWithVisibility, ok := reflect.New(t).Interface().(TypeWithVisibility); ok {
+		return typeWithVisibility.GraphQLVisibility()
+	}
+
+	return ""
+}
+
+// getMarkerTag returns the tag with the given key on the embedded marker
+// struct (groot.InterfaceType or groot.UnionType) of a type
+func getMarkerTag(t reflect.Type, marker reflect.Type, key string) string {
+	for i := 0; i < t.NumField(); i++ {
+		if field := t.Field(i); field.Anonymous && field.Type == marker {
+			return field.Tag.Get(key)
+		}
+	}
+
+	return ""
+}
+
+// getMarkerName returns the name tag on the embedded marker struct of a type,
+// falling back to the name of the Go type without the given suffix. Methods
+// aren't considered since they would be promoted from the embedded types.
+func getMarkerName(t reflect.Type, marker reflect.Type, suffix string) string {
+	if name := getMarkerTag(t, marker, "name"); name != "" {
+		return name
+	}
+
+	return strings.TrimSuffix(getDefaultTypeName(t), suffix)
+}
+
+// getMarkerDescription returns the description tag on the embedded marker
+// struct of a type, falling back to the registered description of the type
+func getMarkerDescription(t reflect.Type, marker reflect.Type) string {
+	if description := getMarkerTag(t, marker, "description"); description != "" {
+		return description
+	}
+
+	return getRegisteredTypeDescription(t)
+}
+
+func getTypeKind(t reflect.Type) (Kind, error) {
+	scalarType := reflect.TypeOf((*ScalarType)(nil)).Elem()
+
 	if parserType, ok := t.(Type); ok {
 		t = parserType.ReflectType()
 	}
@@ -135,6 +258,10 @@ func getTypeKind(t reflect.Type) (Kind, error) {
 		return KindCustomScalar, nil
 	}
 
+	if isTypeEnum(t) {
+		return KindEnum, nil
+	}
+
 	switch t.Kind() {
 	case reflect.Ptr:
 		return KindNullable, nil
@@ -160,15 +287,8 @@ func getTypeKind(t reflect.Type) (Kind, error) {
 		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
 		reflect.Uint, reflect.Uint8, reflect.Uint16,
 		reflect.Float32, reflect.Float64,
-		reflect.Bool:
+		reflect.Bool, reflect.String:
 		return KindScalar, nil
-
-	case reflect.String:
-		if t.Name() == "string" || !t.Implements(enumType) {
-			return KindScalar, nil
-		}
-
-		return KindEnum, nil
 	}
 
 	return KindInvalidType, fmt.Errorf("couldn't parse type %s", t.Name())
@@ -7,12 +7,18 @@ import (
 
 type Union struct {
 	reflectType reflect.Type
+	name        string
+	description string
+	visibility  string
 	members     []*Object
 }
 
 func NewUnion(t reflect.Type) (*Union, error) {
 	union := &Union{
 		reflectType: t,
+		name:        getMarkerName(t, reflect.TypeOf(UnionType{}), ""),
+		description: getMarkerDescription(t, reflect.TypeOf(UnionType{})),
+		visibility:  getMarkerTag(t, reflect.TypeOf(UnionType{}), "visibility"),
 		members:     []*Object{},
 	}
 
@@ -44,6 +50,18 @@ func NewUnion(t reflect.Type) (*Union, error) {
 	return union, nil
 }
 
+func (u *Union) Name() string {
+	return u.name
+}
+
+func (u *Union) Description() string {
+	return u.description
+}
+
+func (u *Union) Visibility() string {
+	return u.visibility
+}
+
 func (u *Union) Members() []*Object {
 	return u.members
 }
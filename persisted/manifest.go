@@ -0,0 +1,106 @@
+package persisted
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+// Manifest is the list of operations approved ahead of time, generated from
+// the operations of clients at build time
+type Manifest struct {
+	// operations are the queries by their id
+	operations map[string]string
+	// queries are the queries by their SHA-256 hash, which is the id used by
+	// automatic persisted queries
+	queries map[string]string
+}
+
+type apolloManifest struct {
+	Format     string `json:"format"`
+	Version    int    `json:"version"`
+	Operations []struct {
+		ID   string `json:"id"`
+		Body string `json:"body"`
+	} `json:"operations"`
+}
+
+// NewManifest returns a manifest with the given queries by their id
+func NewManifest(operations map[string]string) *Manifest {
+	manifest := &Manifest{
+		operations: map[string]string{},
+		queries:    map[string]string{},
+	}
+
+	for id, query := range operations {
+		manifest.operations[id] = query
+		manifest.queries[hashQuery(query)] = query
+	}
+
+	return manifest
+}
+
+// ReadManifest reads a manifest in the format generated by Apollo's
+// generate-persisted-query-manifest, or a JSON object of queries by their id
+// like the one generated by the Relay compiler
+func ReadManifest(r io.Reader) (*Manifest, error) {
+	var raw json.RawMessage
+	if err := json.NewDecoder(r).Decode(&raw); err != nil {
+		return nil, fmt.Errorf("persisted: invalid manifest: %w", err)
+	}
+
+	apollo := apolloManifest{}
+	if err := json.Unmarshal(raw, &apollo); err == nil && apollo.Format == "apollo-persisted-query-manifest" {
+		if apollo.Version != 1 {
+			return nil, fmt.Errorf("persisted: unsupported manifest version %d", apollo.Version)
+		}
+
+		operations := map[string]string{}
+		for _, operation := range apollo.Operations {
+			if operation.ID == "" {
+				return nil, errors.New("persisted: manifest has an operation without an id")
+			}
+
+			operations[operation.ID] = operation.Body
+		}
+
+		return NewManifest(operations), nil
+	}
+
+	operations := map[string]string{}
+	if err := json.Unmarshal(raw, &operations); err != nil {
+		return nil, fmt.Errorf("persisted: invalid manifest: %w", err)
+	}
+
+	return NewManifest(operations), nil
+}
+
+// LoadManifest reads the manifest in the file at path
+func LoadManifest(path string) (*Manifest, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	defer file.Close()
+	return ReadManifest(file)
+}
+
+// Query returns the query with the given id or SHA-256 hash, and whether
+// it's in the manifest
+func (m *Manifest) Query(id string) (string, bool) {
+	if query, ok := m.operations[id]; ok {
+		return query, true
+	}
+
+	query, ok := m.queries[id]
+	return query, ok
+}
+
+// Allows reports whether a query is in the manifest
+func (m *Manifest) Allows(query string) bool {
+	_, ok := m.queries[hashQuery(query)]
+	return ok
+}
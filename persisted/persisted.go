@@ -0,0 +1,263 @@
+// Package persisted implements automatic persisted queries, where clients
+// send the hash of a query instead of the query, and an allowlist mode which
+// only accepts the operations in a manifest of approved operations.
+package persisted
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	// CodeNotFound is the code of errors of requests with the hash of a query
+	// which isn't registered. Clients retry such requests with the query.
+	CodeNotFound = "PERSISTED_QUERY_NOT_FOUND"
+	// CodeNotAllowed is the code of errors of requests with operations which
+	// aren't in the manifest in allowlist mode
+	CodeNotAllowed = "PERSISTED_QUERY_NOT_ALLOWED"
+	// CodeInvalid is the code of errors of requests with an invalid hash, or
+	// an unsupported version of the persisted query extension
+	CodeInvalid = "PERSISTED_QUERY_INVALID"
+)
+
+type Config struct {
+	// Store keeps the queries registered by clients, an in-memory store of
+	// DefaultStoreSize queries by default
+	Store Store
+	// Manifest enables the allowlist mode, where only the operations in the
+	// manifest are accepted, and clients cannot register queries
+	Manifest *Manifest
+}
+
+type persistedQuery struct {
+	Version    int    `json:"version"`
+	SHA256Hash string `json:"sha256Hash"`
+}
+
+type extensions struct {
+	PersistedQuery *persistedQuery `json:"persistedQuery"`
+}
+
+type middleware struct {
+	config Config
+	next   http.Handler
+}
+
+// Middleware returns a middleware for a GraphQL handler, which replaces the
+// hash of a persisted query in the request with the query before passing the
+// request to the handler. GET requests, and POST requests with JSON or
+// application/graphql bodies are supported.
+func Middleware(config Config) func(http.Handler) http.Handler {
+	if config.Store == nil && config.Manifest == nil {
+		config.Store = NewLRUStore(DefaultStoreSize)
+	}
+
+	return func(next http.Handler) http.Handler {
+		return &middleware{config, next}
+	}
+}
+
+func (m *middleware) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var (
+		query string
+		ext   extensions
+		body  map[string]json.RawMessage
+	)
+
+	// graphql-go/handler reads the query from the URL before the body, even
+	// for POST requests, so it must not see a different query than the one
+	// checked here
+	if r.Method == http.MethodPost {
+		removeURLParams(r)
+	}
+
+	contentType := strings.Split(r.Header.Get("Content-Type"), ";")[0]
+	switch {
+	case r.Method == http.MethodGet:
+		query = r.URL.Query().Get("query")
+		if value := r.URL.Query().Get("extensions"); value != "" {
+			if err := json.Unmarshal([]byte(value), &ext); err != nil {
+				writeError(w, http.StatusBadRequest, CodeInvalid, "invalid extensions")
+				return
+			}
+		}
+
+	case r.Method == http.MethodPost && contentType == "application/graphql":
+		rawBody, err := readBody(r)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "", err.Error())
+			return
+		}
+
+		query = string(rawBody)
+
+	case r.Method == http.MethodPost && (contentType == "application/json" || contentType == ""):
+		rawBody, err := readBody(r)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "", err.Error())
+			return
+		}
+
+		if err := json.Unmarshal(rawBody, &body); err != nil {
+			// leave invalid bodies to the handler, unless only approved
+			// operations are accepted
+			if m.config.Manifest != nil {
+				writeError(w, http.StatusBadRequest, "", "invalid request body")
+				return
+			}
+
+			m.next.ServeHTTP(w, r)
+			return
+		}
+
+		json.Unmarshal(body["query"], &query)
+		json.Unmarshal(body["extensions"], &ext)
+
+	default:
+		if m.config.Manifest != nil {
+			writeError(w, http.StatusUnsupportedMediaType, "", "unsupported content type "+contentType)
+			return
+		}
+
+		m.next.ServeHTTP(w, r)
+		return
+	}
+
+	resolvedQuery, err := m.resolveQuery(r, query, ext.PersistedQuery)
+	if err != nil {
+		writeError(w, err.status, err.code, err.message)
+		return
+	}
+
+	if resolvedQuery != query {
+		setQuery(r, body, resolvedQuery)
+	}
+
+	m.next.ServeHTTP(w, r)
+}
+
+// requestError is the error a request is rejected with
+type requestError struct {
+	status  int
+	code    string
+	message string
+}
+
+// resolveQuery returns the query of a request, or the error the request is
+// rejected with
+func (m *middleware) resolveQuery(r *http.Request, query string, persisted *persistedQuery) (string, *requestError) {
+	if persisted != nil && persisted.Version != 1 {
+		return "", &requestError{http.StatusBadRequest, CodeInvalid, "unsupported persisted query version " + strconv.Itoa(persisted.Version)}
+	}
+
+	if manifest := m.config.Manifest; manifest != nil {
+		if persisted != nil {
+			if manifestQuery, ok := manifest.Query(persisted.SHA256Hash); ok {
+				return manifestQuery, nil
+			}
+		}
+
+		if query != "" && manifest.Allows(query) {
+			return query, nil
+		}
+
+		return "", &requestError{http.StatusForbidden, CodeNotAllowed, "PersistedQueryNotAllowed"}
+	}
+
+	if persisted == nil {
+		return query, nil
+	}
+
+	if query != "" {
+		if hashQuery(query) != persisted.SHA256Hash {
+			return "", &requestError{http.StatusBadRequest, CodeInvalid, "provided sha256Hash does not match query"}
+		}
+
+		if err := m.config.Store.Set(r.Context(), persisted.SHA256Hash, query); err != nil {
+			return "", &requestError{http.StatusInternalServerError, "", err.Error()}
+		}
+
+		return query, nil
+	}
+
+	storedQuery, ok, err := m.config.Store.Get(r.Context(), persisted.SHA256Hash)
+	if err != nil {
+		return "", &requestError{http.StatusInternalServerError, "", err.Error()}
+	}
+
+	// clients expect a successful response with the PersistedQueryNotFound
+	// message, after which they retry the request with the query
+	if !ok {
+		return "", &requestError{http.StatusOK, CodeNotFound, "PersistedQueryNotFound"}
+	}
+
+	return storedQuery, nil
+}
+
+// setQuery replaces the query of a request
+func setQuery(r *http.Request, body map[string]json.RawMessage, query string) {
+	if r.Method == http.MethodGet {
+		values := r.URL.Query()
+		values.Set("query", query)
+		r.URL.RawQuery = values.Encode()
+		return
+	}
+
+	var rawBody []byte
+	if body == nil {
+		rawBody = []byte(query)
+	} else {
+		body["query"], _ = json.Marshal(query)
+		rawBody, _ = json.Marshal(body)
+	}
+
+	r.Body = ioutil.NopCloser(bytes.NewReader(rawBody))
+	r.ContentLength = int64(len(rawBody))
+}
+
+// removeURLParams removes the GraphQL parameters from the URL of a request
+func removeURLParams(r *http.Request) {
+	values := r.URL.Query()
+	for _, name := range []string{"query", "variables", "operationName", "extensions"} {
+		values.Del(name)
+	}
+
+	r.URL.RawQuery = values.Encode()
+}
+
+// readBody reads the body of a request, and replaces it so the handler can
+// read it again
+func readBody(r *http.Request) ([]byte, error) {
+	rawBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	r.Body.Close()
+	r.Body = ioutil.NopCloser(bytes.NewReader(rawBody))
+	return rawBody, nil
+}
+
+func writeError(w http.ResponseWriter, status int, code string, message string) {
+	formattedError := map[string]interface{}{"message": message}
+	if code != "" {
+		formattedError["extensions"] = map[string]interface{}{"code": code}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"errors": []interface{}{formattedError},
+	})
+}
+
+func hashQuery(query string) string {
+	hash := sha256.Sum256([]byte(query))
+	return hex.EncodeToString(hash[:])
+}
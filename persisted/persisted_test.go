@@ -0,0 +1,91 @@
+package persisted_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/shreyas44/groot/persisted"
+)
+
+// queryHandler responds with the query of a request, read the way
+// graphql-go/handler reads it, from the URL first and then from the body
+var queryHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query().Get("query")
+	if query == "" {
+		rawBody, _ := ioutil.ReadAll(r.Body)
+		var body struct {
+			Query string `json:"query"`
+		}
+
+		json.Unmarshal(rawBody, &body)
+		query = body.Query
+	}
+
+	w.Write([]byte(query))
+})
+
+func hashQuery(query string) string {
+	hash := sha256.Sum256([]byte(query))
+	return hex.EncodeToString(hash[:])
+}
+
+func serve(handler http.Handler, r *http.Request) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	return w
+}
+
+func newPostRequest(urlQuery string, body string) *http.Request {
+	r := httptest.NewRequest(http.MethodPost, "/graphql?"+urlQuery, strings.NewReader(body))
+	r.Header.Set("Content-Type", "application/json")
+	return r
+}
+
+func TestAllowlistIgnoresURLQueryOfPostRequests(t *testing.T) {
+	manifest := persisted.NewManifest(map[string]string{"hello": "{ hello }"})
+	handler := persisted.Middleware(persisted.Config{Manifest: manifest})(queryHandler)
+
+	r := newPostRequest(
+		"query="+url.QueryEscape("{ secret }"),
+		`{"query": "{ hello }"}`,
+	)
+
+	w := serve(handler, r)
+	if w.Code != http.StatusOK || w.Body.String() != "{ hello }" {
+		t.Fatalf("expected the allowed query to be executed, got %d %q", w.Code, w.Body.String())
+	}
+}
+
+func TestAllowlistRejectsGetRequests(t *testing.T) {
+	manifest := persisted.NewManifest(map[string]string{"hello": "{ hello }"})
+	handler := persisted.Middleware(persisted.Config{Manifest: manifest})(queryHandler)
+
+	r := httptest.NewRequest(http.MethodGet, "/graphql?query="+url.QueryEscape("{ secret }"), nil)
+	if w := serve(handler, r); w.Code != http.StatusForbidden {
+		t.Fatalf("expected status 403, got %d", w.Code)
+	}
+}
+
+func TestPersistedQueryIgnoresURLQueryOfPostRequests(t *testing.T) {
+	handler := persisted.Middleware(persisted.Config{})(queryHandler)
+
+	// register the query
+	query := "{ hello }"
+	extensions := `{"persistedQuery": {"version": 1, "sha256Hash": "` + hashQuery(query) + `"}}`
+	r := newPostRequest("", `{"query": "{ hello }", "extensions": `+extensions+`}`)
+	if w := serve(handler, r); w.Body.String() != query {
+		t.Fatalf("expected %q, got %q", query, w.Body.String())
+	}
+
+	r = newPostRequest("query="+url.QueryEscape("{ secret }"), `{"extensions": `+extensions+`}`)
+	if w := serve(handler, r); w.Body.String() != query {
+		t.Fatalf("expected %q, got %q", query, w.Body.String())
+	}
+}
@@ -0,0 +1,44 @@
+package persisted
+
+import (
+	"context"
+
+	"github.com/shreyas44/groot/internal/lru"
+)
+
+// DefaultStoreSize is the number of queries kept by the default store
+const DefaultStoreSize = 1000
+
+// Store keeps the queries registered by clients by their SHA-256 hash. It can
+// be implemented with Redis or any other shared cache to share queries
+// between instances of a server.
+type Store interface {
+	// Get returns the query with the given hash, and whether it's found
+	Get(ctx context.Context, hash string) (string, bool, error)
+	// Set registers a query with its hash
+	Set(ctx context.Context, hash string, query string) error
+}
+
+type lruStore struct {
+	cache *lru.Cache
+}
+
+// NewLRUStore returns an in-memory store which keeps up to size queries,
+// evicting the least recently used queries first
+func NewLRUStore(size int) Store {
+	return &lruStore{lru.New(size)}
+}
+
+func (s *lruStore) Get(ctx context.Context, hash string) (string, bool, error) {
+	query, ok := s.cache.Get(hash)
+	if !ok {
+		return "", false, nil
+	}
+
+	return query.(string), true, nil
+}
+
+func (s *lruStore) Set(ctx context.Context, hash string, query string) error {
+	s.cache.Set(hash, query)
+	return nil
+}
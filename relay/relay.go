@@ -33,8 +33,8 @@ type PageInfo struct {
 }
 
 type PaginationArgs struct {
-	First  *int    `json:"first"`
-	Last   *int    `json:"last"`
+	First  *int    `json:"first" multiplier:"true"`
+	Last   *int    `json:"last" multiplier:"true"`
 	After  *string `json:"after"`
 	Before *string `json:"before"`
 }
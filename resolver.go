@@ -2,6 +2,7 @@ package groot
 
 import (
 	"encoding/json"
+	"errors"
 	"reflect"
 
 	"github.com/graphql-go/graphql"
@@ -34,6 +35,7 @@ func newInputArgsValidator(input *parser.Input) inputArgsValidator {
 	}
 
 	for _, arg := range input.Arguments() {
+		arg := arg
 		if validator := arg.Validator(); validator != nil {
 			validator := func(v reflect.Value) error {
 				var (
@@ -54,10 +56,10 @@ func newInputArgsValidator(input *parser.Input) inputArgsValidator {
 		}
 
 		if input, ok := arg.Type().(*parser.Input); ok {
-			validator := newInputArgsValidator(input)
-			validator = func(v reflect.Value) error {
-				field := v.FieldByName(arg.Type().ReflectType().Name())
-				return validator(field)
+			validateInput := newInputArgsValidator(input)
+			validator := func(v reflect.Value) error {
+				field := v.FieldByName(arg.StructField().Name)
+				return validateInput(field)
 			}
 
 			validators = append(validators, validator)
@@ -75,16 +77,68 @@ func newInputArgsValidator(input *parser.Input) inputArgsValidator {
 	}
 }
 
-func newFieldResolver(field *parser.Field) fieldResolver {
+func newFieldResolver(field *parser.Field, builder *SchemaBuilder) fieldResolver {
 	if field.Subscriber() != nil {
 		return newSubsriberFieldResolver(field)
 	}
 
-	if field.Resolver() == nil {
-		return newDefaultFieldResolver(field)
+	if field.Transformer() != nil {
+		resolve := newCustomFieldResolver(field.Transformer(), builder)
+		return newPresentingFieldResolver(builder.instrumentFieldResolver(field, nil, resolve))
+	}
+
+	resolver := builder.getResolver(field)
+	if resolver == nil {
+		return builder.instrumentFieldResolver(field, nil, newDefaultFieldResolver(field, builder))
+	}
+
+	resolve := newCustomFieldResolver(resolver, builder)
+
+	// mutations are executed serially, unless explicitly marked async
+	isMutation := builder.mutation != nil && field.Object() == parser.TypeWithFields(builder.mutation)
+	isAsync := field.Async() || (builder.asyncResolvers && !isMutation)
+	if isAsync {
+		resolve = newAsyncFieldResolver(resolve)
+	}
+
+	timeout, hasTimeout := field.Timeout()
+	if !hasTimeout {
+		timeout = builder.defaultTimeout
+	}
+
+	if timeout > 0 {
+		resolve = newTimeoutFieldResolver(resolve, timeout, isAsync)
 	}
 
-	return newCustomFieldResolver(field.Resolver())
+	// instrumented outside the timeout, so fields which time out finish with
+	// the timeout error instead of when the abandoned resolver returns
+	resolve = builder.instrumentFieldResolver(field, resolver, resolve)
+	return newPresentingFieldResolver(resolve)
+}
+
+// newValueFieldResolver resolves a field to the value fn returns for the value
+// the resolver returns, or the value of the thunk it returns
+func newValueFieldResolver(resolve fieldResolver, fn func(p graphql.ResolveParams, value interface{}) (interface{}, error)) fieldResolver {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		value, err := resolve(p)
+		if err != nil {
+			return value, err
+		}
+
+		thunk, isThunk := value.(func() (interface{}, error))
+		if !isThunk {
+			return fn(p, value)
+		}
+
+		return func() (interface{}, error) {
+			value, err := thunk()
+			if err != nil {
+				return value, err
+			}
+
+			return fn(p, value)
+		}, nil
+	}
 }
 
 func newSubsriberFieldResolver(field *parser.Field) fieldSubscriber {
@@ -93,7 +147,9 @@ func newSubsriberFieldResolver(field *parser.Field) fieldSubscriber {
 	}
 }
 
-func newDefaultFieldResolver(field *parser.Field) fieldResolver {
+func newDefaultFieldResolver(field *parser.Field, builder *SchemaBuilder) fieldResolver {
+	omitEmpty := builder.omitEmptyNullable && field.OmitEmpty()
+
 	return func(p graphql.ResolveParams) (interface{}, error) {
 		value := reflect.ValueOf(p.Source)
 		name := field.StructField().Name
@@ -102,56 +158,93 @@ func newDefaultFieldResolver(field *parser.Field) fieldResolver {
 			value = value.Elem()
 		}
 
-		if _, ok := field.Type().(*parser.Enum); ok {
-			return value.FieldByName(name).Convert(reflect.TypeOf("")).Interface(), nil
+		fieldValue := value.FieldByName(name)
+		if omitEmpty && fieldValue.IsZero() {
+			return nil, nil
+		}
+
+		if field.AsString() {
+			return stringifyValue(fieldValue)
+		}
+
+		return fieldValue.Interface(), nil
+	}
+}
+
+// stringifyValue formats a field with the string json option the same way
+// encoding/json does
+func stringifyValue(value reflect.Value) (interface{}, error) {
+	if value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return nil, nil
 		}
 
-		return value.FieldByName(name).Interface(), nil
+		value = value.Elem()
 	}
+
+	str, err := json.Marshal(value.Interface())
+	if err != nil {
+		return nil, err
+	}
+
+	return string(str), nil
 }
 
-func newCustomFieldResolver(resolver *parser.Resolver) fieldResolver {
+func newCustomFieldResolver(resolver *parser.Resolver, builder *SchemaBuilder) fieldResolver {
 	parserReturnType := resolver.Field().Type()
 	resolverFunc := resolver.ReflectMethod().Func
-	validateInputArgs := newInputArgsValidator(resolver.Field().ArgsInput())
+	validateInputArgs := newInputArgsValidator(resolver.ArgsInput())
+	builder.checkDependencies(resolver)
+
+	// transformers define the type of the field, resolvers can return any type
+	// compatible with the type of the struct field
+	var returnType reflect.Type
+	if resolver != resolver.Field().Transformer() {
+		returnType = resolver.Field().StructField().Type
+	}
 
 	if !resolver.ReturnsThunk() {
 		return func(p graphql.ResolveParams) (interface{}, error) {
-			args, err := makeResolverArgs(resolver, validateInputArgs, p)
+			args, err := makeResolverArgs(builder, resolver, validateInputArgs, p)
 			if err != nil {
 				return nil, err
 			}
 
 			response := resolverFunc.Call(args)
+			response[0] = adaptOutputValue(response[0], returnType)
 			return makeResolverOutput(p, parserReturnType, response)
 		}
 	}
 
 	return func(p graphql.ResolveParams) (interface{}, error) {
-		args, err := makeResolverArgs(resolver, validateInputArgs, p)
+		args, err := makeResolverArgs(builder, resolver, validateInputArgs, p)
 		if err != nil {
 			return nil, err
 		}
 
 		response := resolverFunc.Call(args)
-		thunk, resErr := response[0], response[1]
-		if !resErr.IsNil() {
-			return nil, resErr.Interface().(error)
+		thunk := response[0]
+		if len(response) == 2 {
+			if err := makeResolverError(p, response[1]); err != nil {
+				return nil, err
+			}
 		}
 
 		return func() (interface{}, error) {
 			output := thunk.Call([]reflect.Value{})
+			output[0] = adaptOutputValue(output[0], returnType)
 			return makeResolverOutput(p, parserReturnType, output)
 		}, nil
 	}
 }
 
-func newFieldSubscriber(subscriber *parser.Subscriber, parserType parser.Type) fieldResolver {
+func newFieldSubscriber(subscriber *parser.Subscriber, parserType parser.Type, builder *SchemaBuilder) fieldResolver {
 	subscriberFunc := subscriber.ReflectMethod().Func
-	validateInputArgs := newInputArgsValidator(subscriber.Field().ArgsInput())
+	validateInputArgs := newInputArgsValidator(subscriber.ArgsInput())
+	builder.checkDependencies(subscriber)
 
 	return func(p graphql.ResolveParams) (interface{}, error) {
-		args, err := makeResolverArgs(subscriber, validateInputArgs, p)
+		args, err := makeResolverArgs(builder, subscriber, validateInputArgs, p)
 		if err != nil {
 			return nil, err
 		}
@@ -186,8 +279,7 @@ func newFieldSubscriber(subscriber *parser.Subscriber, parserType parser.Type) f
 
 		go func() {
 			for value := range valueCh {
-				response := []reflect.Value{value, reflect.ValueOf((*error)(nil))}
-				output, _ := makeResolverOutput(p, parserType, response)
+				output, _ := makeResolverOutput(p, parserType, []reflect.Value{value})
 				ch <- output
 			}
 
@@ -198,7 +290,7 @@ func newFieldSubscriber(subscriber *parser.Subscriber, parserType parser.Type) f
 	}
 }
 
-func makeResolverArgs(resolver *parser.Resolver, validateInputArgs inputArgsValidator, p graphql.ResolveParams) ([]reflect.Value, error) {
+func makeResolverArgs(builder *SchemaBuilder, resolver *parser.Resolver, validateInputArgs inputArgsValidator, p graphql.ResolveParams) ([]reflect.Value, error) {
 	var (
 		resolverMethod = resolver.ReflectMethod()
 		resolverFunc   = resolverMethod.Func
@@ -206,24 +298,27 @@ func makeResolverArgs(resolver *parser.Resolver, validateInputArgs inputArgsVali
 		args           = []reflect.Value{}
 	)
 
-	// if it's a map, it's a root query
-	if _, isMap := p.Source.(map[string]interface{}); isMap {
-		args = append(args, reflect.Indirect(reflect.New(resolverMethod.Type.In(0))))
+	if receiver := resolver.Receiver(); receiver.IsValid() {
+		args = append(args, receiver)
 	} else {
-		value := reflect.ValueOf(p.Source)
-		if value.Kind() == reflect.Ptr {
-			value = value.Elem()
-		}
-
-		args = append(args, value)
+		args = append(args, makeSourceValue(p, funcType.In(0)))
 	}
 
-	for _, arg := range resolver.ArgsSignature() {
+	for i, arg := range resolver.ArgsSignature() {
+		// skip the receiver
+		argType := funcType.In(i + 1)
+
 		switch arg {
+		case parser.ResolverArgObject:
+			args = append(args, makeSourceValue(p, argType))
+		case parser.ResolverArgFieldValue:
+			source := reflect.Indirect(makeSourceValue(p, funcType.In(0)))
+			args = append(args, source.FieldByName(resolver.Field().StructField().Name))
 		case parser.ResolverArgInput:
 			// TODO: figure out a better way to do this instead of marshalling and unmarshalling
-			structInterface := reflect.New(funcType.In(1)).Interface()
-			jsonBytes, err := json.Marshal(p.Args)
+			structInterface := reflect.New(argType).Interface()
+			jsonArgs := builder.argsToJSON(resolver.ArgsInput(), p.Args)
+			jsonBytes, err := json.Marshal(jsonArgs)
 			if err != nil {
 				return nil, err
 			}
@@ -231,7 +326,7 @@ func makeResolverArgs(resolver *parser.Resolver, validateInputArgs inputArgsVali
 			json.Unmarshal(jsonBytes, &structInterface)
 			inputArgs := reflect.Indirect(reflect.ValueOf(structInterface))
 			if err := validateInputArgs(inputArgs); err != nil {
-				return nil, err
+				return nil, newInputError(err)
 			}
 
 			args = append(args, inputArgs)
@@ -239,16 +334,95 @@ func makeResolverArgs(resolver *parser.Resolver, validateInputArgs inputArgsVali
 			args = append(args, reflect.ValueOf(p.Context))
 		case parser.ResolverArgInfo:
 			args = append(args, reflect.ValueOf(p.Info))
+		case parser.ResolverArgDependency:
+			// checked when the schema is built
+			provider, _ := builder.container.getProvider(argType)
+			value, err := provider(p.Context)
+			if err != nil {
+				return nil, err
+			}
+
+			args = append(args, value)
 		}
 	}
 
 	return args, nil
 }
 
+// makeSourceValue returns the object being resolved as a value of type t,
+// which is either the type of the object or a pointer to it
+func makeSourceValue(p graphql.ResolveParams, t reflect.Type) reflect.Value {
+	// if it's a map, it's a root query
+	if _, isMap := p.Source.(map[string]interface{}); isMap {
+		if t.Kind() == reflect.Ptr {
+			return reflect.New(t.Elem())
+		}
+
+		return reflect.New(t).Elem()
+	}
+
+	value := reflect.ValueOf(p.Source)
+	if t.Kind() != reflect.Ptr {
+		return reflect.Indirect(value)
+	}
+
+	if value.Kind() == reflect.Ptr {
+		return value
+	}
+
+	// the value isn't addressable, so the method receives a pointer to a copy
+	ptr := reflect.New(value.Type())
+	ptr.Elem().Set(value)
+	return ptr
+}
+
+// argsToJSON renames the keys of the arguments received from graphql-go, and
+// of any input objects in them, from their GraphQL names to their JSON names
+func (builder *SchemaBuilder) argsToJSON(parserType parser.Type, value interface{}) interface{} {
+	switch parserType := parserType.(type) {
+	case *parser.Nullable:
+		if value == nil {
+			return nil
+		}
+
+		return builder.argsToJSON(parserType.Element(), value)
+
+	case *parser.Array:
+		list, ok := value.([]interface{})
+		if !ok {
+			return value
+		}
+
+		jsonList := make([]interface{}, len(list))
+		for i, item := range list {
+			jsonList[i] = builder.argsToJSON(parserType.Element(), item)
+		}
+
+		return jsonList
+
+	case *parser.Input:
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return value
+		}
+
+		jsonObject := map[string]interface{}{}
+		for _, arg := range parserType.Arguments() {
+			if argValue, ok := object[builder.argumentName(arg)]; ok {
+				jsonObject[arg.JSONName()] = builder.argsToJSON(arg.Type(), argValue)
+			}
+		}
+
+		return jsonObject
+	}
+
+	return value
+}
+
 func makeResolverOutput(p graphql.ResolveParams, parserType parser.Type, response []reflect.Value) (interface{}, error) {
 	var union *parser.Union
 	var isUnion bool
-	value, resErr := response[0], response[1]
+	value := response[0]
 
 	if nullable, isNullable := parserType.(*parser.Nullable); isNullable {
 		union, isUnion = nullable.Element().(*parser.Union)
@@ -266,9 +440,84 @@ func makeResolverOutput(p graphql.ResolveParams, parserType parser.Type, respons
 		value = resolveUnionValue(union, p)
 	}
 
-	if resErr.IsNil() {
+	if len(response) == 1 {
 		return value.Interface(), nil
 	}
 
-	return value.Interface(), resErr.Interface().(error)
+	// only []error and errors created with ErrorAt make the field resolve to
+	// the value along with the errors, any other error makes it resolve to
+	// null
+	if err := makeResolverError(p, response[1]); err != nil {
+		return nil, err
+	}
+
+	return value.Interface(), nil
+}
+
+// adaptOutputValue converts a value returned by a resolver to the type of the
+// field it resolves, following the rules the parser checks the return types
+// of resolvers with
+func adaptOutputValue(value reflect.Value, t reflect.Type) reflect.Value {
+	if t == nil || value.Type() == t {
+		return value
+	}
+
+	switch {
+	case value.Type().AssignableTo(t):
+		return value
+	case value.Kind() == t.Kind() && value.Type().ConvertibleTo(t) && t.Kind() != reflect.Slice:
+		return value.Convert(t)
+	case t.Kind() == reflect.Ptr && value.Kind() != reflect.Ptr:
+		// nil slices and maps returned for nullable fields resolve to null
+		if (value.Kind() == reflect.Slice || value.Kind() == reflect.Map) && value.IsNil() {
+			return reflect.Zero(t)
+		}
+
+		ptr := reflect.New(t.Elem())
+		ptr.Elem().Set(adaptOutputValue(value, t.Elem()))
+		return ptr
+	case t.Kind() == reflect.Slice && value.Kind() == reflect.Slice:
+		if value.IsNil() {
+			return reflect.Zero(t)
+		}
+
+		slice := reflect.MakeSlice(t, value.Len(), value.Len())
+		for i := 0; i < value.Len(); i++ {
+			slice.Index(i).Set(adaptOutputValue(value.Index(i), t.Elem()))
+		}
+
+		return slice
+	}
+
+	return value
+}
+
+// makeResolverError returns the error a resolver returned, either as an error
+// or as []error. Errors returned as []error and errors created with ErrorAt
+// don't prevent the field from resolving to the value the resolver returned.
+func makeResolverError(p graphql.ResolveParams, resErr reflect.Value) error {
+	if errs, ok := resErr.Interface().([]error); ok {
+		nonNilErrs := []error{}
+		for _, err := range errs {
+			if err != nil {
+				nonNilErrs = append(nonNilErrs, err)
+			}
+		}
+
+		return addFieldErrors(p, nonNilErrs)
+	}
+
+	if resErr.IsNil() {
+		return nil
+	}
+
+	// errors of values inside the returned value don't prevent the field from
+	// resolving to it
+	err := resErr.Interface().(error)
+	var pathErr *PathError
+	if errors.As(err, &pathErr) {
+		return addFieldErrors(p, []error{err})
+	}
+
+	return err
 }
@@ -0,0 +1,98 @@
+package groot_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/shreyas44/groot"
+)
+
+type PointerQuery struct {
+	Greeting string `json:"greeting"`
+	prefix   string
+}
+
+func (query *PointerQuery) ResolveGreeting() (string, error) {
+	return query.prefix + "hello", nil
+}
+
+func TestPointerReceiverOnRootField(t *testing.T) {
+	schema, err := groot.NewSchema(groot.SchemaConfig{
+		Query: groot.MustParseObject(PointerQuery{}),
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := graphql.Do(graphql.Params{Schema: schema, RequestString: "{ greeting }"})
+	assertResult(t, result, `{"greeting":"hello"}`)
+}
+
+type PartialResultQuery struct {
+	Names  *[]string `json:"names"`
+	Listed []string  `json:"listed"`
+	Items  []string  `json:"items"`
+}
+
+// ResolveNames returns a value along with a plain error, which isn't a
+// partial result
+func (query PartialResultQuery) ResolveNames() ([]string, error) {
+	return []string{"a"}, groot.NewError("FAILED", "names failed to load")
+}
+
+func (query PartialResultQuery) ResolveListed() ([]string, []error) {
+	return []string{"a", ""}, []error{nil, groot.NewError("PARTIAL", "b failed to load")}
+}
+
+func (query PartialResultQuery) ResolveItems() ([]string, error) {
+	return []string{"a", ""}, groot.ErrorAt(groot.NewError("PARTIAL", "b failed to load"), 1)
+}
+
+func TestPartialResult(t *testing.T) {
+	schema, err := groot.NewSchema(groot.SchemaConfig{
+		Query: groot.MustParseObject(PartialResultQuery{}),
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := graphql.Do(graphql.Params{Schema: schema, RequestString: "{ names listed items }"})
+	data, _ := json.Marshal(result.Data)
+	if string(data) != `{"items":["a",""],"listed":["a",""],"names":null}` {
+		t.Fatalf("unexpected data %s", data)
+	}
+
+	paths := map[string]bool{}
+	for _, err := range result.Errors {
+		paths[fmt.Sprint(err.Path)] = true
+	}
+
+	expected := map[string]bool{"[names]": true, "[listed]": true, "[items 1]": true}
+	if !reflect.DeepEqual(paths, expected) {
+		t.Fatalf("expected errors at %v, got %v", expected, result.Errors)
+	}
+}
+
+// assertResult fails the test if the result has errors, or its data isn't
+// the expected JSON
+func assertResult(t *testing.T, result *graphql.Result, expected string) {
+	t.Helper()
+
+	if len(result.Errors) != 0 {
+		t.Fatalf("unexpected errors: %v", result.Errors)
+	}
+
+	data, err := json.Marshal(result.Data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(data) != expected {
+		t.Fatalf("expected %s, got %s", expected, data)
+	}
+}
@@ -14,6 +14,8 @@ type ScalarType = parser.ScalarType
 
 type ID string
 
+type ScalarTypeWithSpecifiedBy = parser.ScalarTypeWithSpecifiedBy
+
 var builtinScalars = map[reflect.Type]*graphql.Scalar{
 	reflect.TypeOf(ID("")):       graphql.ID,
 	reflect.TypeOf(int(0)):       graphql.Int,
@@ -34,9 +36,9 @@ func NewScalar(parserScalar *parser.Scalar, builder *SchemaBuilder) *graphql.Sca
 		return graphqlScalar
 	}
 
-	// TODO: description
 	scalar := graphql.NewScalar(graphql.ScalarConfig{
-		Name: parserScalar.ReflectType().Name(),
+		Name:        parserScalar.Name(),
+		Description: parserScalar.Description(),
 		Serialize: func(value interface{}) interface{} {
 			var v ScalarType
 			if reflect.TypeOf(value).Kind() != reflect.Ptr {
@@ -1,7 +1,9 @@
 package groot
 
 import (
+	"fmt"
 	"reflect"
+	"time"
 
 	"github.com/graphql-go/graphql"
 	"github.com/shreyas44/groot/parser"
@@ -13,18 +15,157 @@ type SchemaConfig struct {
 	Subscription *parser.Object
 	Types        []parser.Type
 	Extensions   []graphql.Extension
+
+	// Resolvers are structs with resolvers for fields of objects, parsed with
+	// ParseResolvers
+	Resolvers []*parser.ResolverSet
+	// Container provides the dependencies resolvers accept apart from their
+	// arguments, the context and graphql.ResolveInfo
+	Container *Container
+
+	// FieldNaming is applied to the Go names of fields and arguments that
+	// don't have their name set with the graphql or json tag
+	FieldNaming NameFunc
+	// EnumValueNaming is applied to the names of all enum values
+	EnumValueNaming NameFunc
+
+	// OmitEmptyNullable makes fields and arguments with the omitempty json
+	// option nullable, and resolves zero values of such fields to null
+	OmitEmptyNullable bool
+
+	// AsyncResolvers runs all resolvers in goroutines, instead of only the
+	// resolvers of fields with the async tag
+	AsyncResolvers bool
+	// MaxConcurrency is the number of resolvers run in goroutines at the same
+	// time for a request, DefaultMaxConcurrency by default
+	MaxConcurrency int
+
+	// DefaultTimeout is the timeout of resolvers of fields without the timeout
+	// tag, after which the field resolves to a TimeoutError
+	DefaultTimeout time.Duration
+
+	// ErrorPresenter converts the errors returned by resolvers to the errors
+	// sent to clients
+	ErrorPresenter ErrorPresenter
+
+	// Authorizer authorizes access to fields before they are resolved, see
+	// the auth package
+	Authorizer Authorizer
+	// HideUnauthorizedFields leaves fields with rules that deny a request out
+	// of the results of introspection queries of the request. The schema
+	// isn't changed, so the fields can still be queried and fail as usual.
+	HideUnauthorizedFields bool
+
+	// Visible reports whether fields, arguments, enum values and types with
+	// the given visibility, set with the visibility tag, are included in the
+	// schema. Everything with a visibility is dropped if it isn't set, which
+	// lets different schemas be built from the same types.
+	Visible func(visibility string) bool
 }
 
 type SchemaBuilder struct {
-	graphqlTypes    map[parser.Type]graphql.Type
-	reflectGrootMap map[reflect.Type]graphql.Type
+	graphqlTypes      map[parser.Type]graphql.Type
+	reflectGrootMap   map[reflect.Type]graphql.Type
+	namedTypes        map[string]parser.Type
+	resolvers         map[*parser.Field]*parser.Resolver
+	container         *Container
+	fieldNaming       NameFunc
+	enumValueNaming   NameFunc
+	omitEmptyNullable bool
+	asyncResolvers    bool
+	mutation          *parser.Object
+	defaultTimeout    time.Duration
+	authorizer        Authorizer
+	visible           func(visibility string) bool
+	hasHidden         bool
+	fieldCosts        map[fieldKey]*fieldCost
+	fieldRules        map[fieldKey]FieldRule
+	fieldExtensions   []FieldExtension
+	extensions        []graphql.Extension
+	err               error
 }
 
 func (builder *SchemaBuilder) addType(t parser.Type, graphqlType graphql.Type) {
+	name := graphqlType.Name()
+	if existing, ok := builder.namedTypes[name]; ok && existing != t {
+		builder.addError(fmt.Errorf(
+			"Go types %s and %s both have the GraphQL name %s, implement GraphQLName() on one of them to give it a different name",
+			reflectTypeString(existing.ReflectType()),
+			reflectTypeString(t.ReflectType()),
+			name,
+		))
+	}
+
+	builder.namedTypes[name] = t
 	builder.graphqlTypes[t] = graphqlType
 	builder.reflectGrootMap[t.ReflectType()] = graphqlType
 }
 
+func (builder *SchemaBuilder) addResolverSet(resolverSet *parser.ResolverSet) {
+	for _, resolver := range resolverSet.Resolvers() {
+		field := resolver.Field()
+		if existing, ok := builder.resolvers[field]; ok {
+			builder.addError(fmt.Errorf(
+				"field %s on struct %s is resolved by both %s and %s",
+				field.StructField().Name,
+				field.Object().ReflectType().Name(),
+				existing.Receiver().Type(),
+				resolver.Receiver().Type(),
+			))
+		}
+
+		if field.AsString() {
+			builder.addError(fmt.Errorf(
+				"field %s on struct %s cannot have both the string json option and a resolver",
+				field.StructField().Name,
+				field.Object().ReflectType().Name(),
+			))
+		}
+
+		builder.resolvers[field] = resolver
+	}
+}
+
+// getResolver returns the resolver of a field, which is either a resolver
+// from a resolver set or the resolver defined on the object
+func (builder *SchemaBuilder) getResolver(field *parser.Field) *parser.Resolver {
+	if resolver, ok := builder.resolvers[field]; ok {
+		return resolver
+	}
+
+	return field.Resolver()
+}
+
+// checkDependencies adds an error if the container has no provider for any of
+// the dependencies a resolver accepts
+func (builder *SchemaBuilder) checkDependencies(resolver *parser.Resolver) {
+	funcType := resolver.ReflectMethod().Func.Type()
+	for i, arg := range resolver.ArgsSignature() {
+		if arg != parser.ResolverArgDependency {
+			continue
+		}
+
+		// skip the receiver
+		argType := funcType.In(i + 1)
+		if _, ok := builder.container.getProvider(argType); !ok {
+			builder.addError(fmt.Errorf(
+				"no provider for parameter of type %s of resolver %s on struct %s",
+				argType,
+				resolver.ReflectMethod().Name,
+				resolver.Field().Object().ReflectType().Name(),
+			))
+		}
+	}
+}
+
+// addError records an error found while building the schema, which is
+// returned by NewSchema. Only the first error is kept.
+func (builder *SchemaBuilder) addError(err error) {
+	if builder.err == nil {
+		builder.err = err
+	}
+}
+
 func (builder *SchemaBuilder) getType(t parser.Type) (graphql.Type, bool) {
 	graphqlType, ok := builder.graphqlTypes[t]
 	if ok {
@@ -38,16 +179,50 @@ func NewSchemaBuilder() *SchemaBuilder {
 	return &SchemaBuilder{
 		graphqlTypes:    map[parser.Type]graphql.Type{},
 		reflectGrootMap: map[reflect.Type]graphql.Type{},
+		namedTypes:      map[string]parser.Type{},
+		resolvers:       map[*parser.Field]*parser.Resolver{},
+		fieldCosts:      map[fieldKey]*fieldCost{},
+		fieldRules:      map[fieldKey]FieldRule{},
 	}
 }
 
 func NewSchema(config SchemaConfig) (graphql.Schema, error) {
+	schema, _, err := buildSchema(config)
+	return schema, err
+}
+
+// buildSchema builds the schema of a config, returning the builder along with
+// it for the state Executor needs
+func buildSchema(config SchemaConfig) (graphql.Schema, *SchemaBuilder, error) {
 	builder := NewSchemaBuilder()
+	builder.fieldNaming = config.FieldNaming
+	builder.enumValueNaming = config.EnumValueNaming
+	builder.omitEmptyNullable = config.OmitEmptyNullable
+	builder.container = config.Container
+	builder.asyncResolvers = config.AsyncResolvers
+	builder.mutation = config.Mutation
+	builder.defaultTimeout = config.DefaultTimeout
+	builder.authorizer = config.Authorizer
+	builder.visible = config.Visible
+	for _, resolverSet := range config.Resolvers {
+		builder.addResolverSet(resolverSet)
+	}
+	for _, ext := range config.Extensions {
+		if fieldExtension, ok := ext.(FieldExtension); ok {
+			builder.fieldExtensions = append(builder.fieldExtensions, fieldExtension)
+		}
+	}
+	ext := newExtension(config)
 	schemaConfig := graphql.SchemaConfig{
-		Extensions: config.Extensions,
+		Extensions: []graphql.Extension{ext},
 		Types:      []graphql.Type{},
 	}
 
+	for _, ext := range config.Extensions {
+		schemaConfig.Extensions = append(schemaConfig.Extensions, orderedExtension{ext})
+	}
+
+	builder.checkRootTypes(config.Query, config.Mutation, config.Subscription)
 	if config.Query != nil {
 		schemaConfig.Query = NewObject(config.Query, builder)
 	}
@@ -60,9 +235,33 @@ func NewSchema(config SchemaConfig) (graphql.Schema, error) {
 		schemaConfig.Subscription = NewObject(config.Subscription, builder)
 	}
 
-	for _, t := range config.Types {
+	for _, t := range builder.getVisibleTypes(config.Types) {
 		schemaConfig.Types = append(schemaConfig.Types, getOrCreateType(t, builder))
 	}
 
-	return graphql.NewSchema(schemaConfig)
+	if builder.err != nil {
+		return graphql.Schema{}, nil, builder.err
+	}
+
+	schema, err := graphql.NewSchema(schemaConfig)
+	if err != nil {
+		return graphql.Schema{}, nil, err
+	}
+
+	if config.HideUnauthorizedFields {
+		ext.fieldRules = builder.getFieldDefinitionRules()
+	}
+
+	builder.extensions = schemaConfig.Extensions
+	return schema, builder, nil
+}
+
+// reflectTypeString returns the name of a type including the full path of its
+// package, to tell apart types with the same name from different packages
+func reflectTypeString(t reflect.Type) string {
+	if t.PkgPath() == "" {
+		return t.String()
+	}
+
+	return t.PkgPath() + "." + t.Name()
 }
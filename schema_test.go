@@ -0,0 +1,102 @@
+package groot_test
+
+import (
+	"testing"
+
+	"github.com/shreyas44/groot"
+	"github.com/shreyas44/groot/parser"
+)
+
+type StringResolvedQuery struct {
+	Count int64 `json:"count,string"`
+}
+
+func (query StringResolvedQuery) ResolveCount() (int64, error) {
+	return 1, nil
+}
+
+type StringQuery struct {
+	Count int64 `json:"count,string"`
+}
+
+type StringQueryResolvers struct{}
+
+func (resolvers StringQueryResolvers) ResolveCount(query StringQuery) (int64, error) {
+	return 1, nil
+}
+
+func TestStringOptionWithResolver(t *testing.T) {
+	if _, err := groot.ParseObject(StringResolvedQuery{}); err == nil {
+		t.Fatal("expected an error for a field with the string option and a resolver")
+	}
+
+	_, err := groot.NewSchema(groot.SchemaConfig{
+		Query:     groot.MustParseObject(StringQuery{}),
+		Resolvers: []*parser.ResolverSet{groot.MustParseResolvers(StringQueryResolvers{})},
+	})
+
+	if err == nil {
+		t.Fatal("expected an error for a field with the string option and a resolver set")
+	}
+}
+
+type NamedBase struct {
+	ID string `json:"id"`
+}
+
+func (base NamedBase) GraphQLName() string {
+	return "Base"
+}
+
+type NamedPointerBase struct {
+	Name string `json:"name"`
+}
+
+func (base *NamedPointerBase) GraphQLName() string {
+	return "PointerBase"
+}
+
+// EmbeddingNamed and EmbeddingPointerNamed don't get the names of the types
+// they embed
+type EmbeddingNamed struct {
+	NamedBase
+	Extra string `json:"extra"`
+}
+
+type EmbeddingPointerNamed struct {
+	NamedPointerBase
+	Extra string `json:"extra"`
+}
+
+type RenamedEmbeddingNamed struct {
+	NamedBase
+	Other string `json:"other"`
+}
+
+func (renamed *RenamedEmbeddingNamed) GraphQLName() string {
+	return "Renamed"
+}
+
+type NamedQuery struct {
+	Base     NamedBase             `json:"base"`
+	Pointer  NamedPointerBase      `json:"pointer"`
+	Embedded EmbeddingNamed        `json:"embedded"`
+	Pointers EmbeddingPointerNamed `json:"pointers"`
+	Renamed  RenamedEmbeddingNamed `json:"renamed"`
+}
+
+func TestTypeNameIsNotPromoted(t *testing.T) {
+	schema, err := groot.NewSchema(groot.SchemaConfig{
+		Query: groot.MustParseObject(NamedQuery{}),
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{"Base", "PointerBase", "EmbeddingNamed", "EmbeddingPointerNamed", "Renamed"} {
+		if schema.Type(name) == nil {
+			t.Errorf("expected type %s in the schema", name)
+		}
+	}
+}
@@ -0,0 +1,261 @@
+package groot
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+	"github.com/shreyas44/groot/parser"
+)
+
+// PrintSchema builds the schema of a config and prints it in the GraphQL
+// schema definition language. Unlike SDL printed from introspection with
+// graphql-go, default values are printed as literals of their type, and
+// scalars have the @specifiedBy directive.
+func PrintSchema(config SchemaConfig) (string, error) {
+	schema, builder, err := buildSchema(config)
+	if err != nil {
+		return "", err
+	}
+
+	return builder.printSchema(schema), nil
+}
+
+var builtinScalarNames = map[string]bool{
+	"String":  true,
+	"Int":     true,
+	"Float":   true,
+	"Boolean": true,
+	"ID":      true,
+}
+
+func (builder *SchemaBuilder) printSchema(schema graphql.Schema) string {
+	definitions := []string{}
+	if definition := printSchemaDefinition(schema); definition != "" {
+		definitions = append(definitions, definition)
+	}
+
+	names := []string{}
+	for name := range schema.TypeMap() {
+		if !strings.HasPrefix(name, "__") && !builtinScalarNames[name] {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+	for _, name := range names {
+		definitions = append(definitions, builder.printType(schema.Type(name)))
+	}
+
+	return strings.Join(definitions, "\n\n") + "\n"
+}
+
+// printSchemaDefinition prints the schema definition if the root types don't
+// have the default names
+func printSchemaDefinition(schema graphql.Schema) string {
+	roots := []struct {
+		operation   string
+		t           *graphql.Object
+		defaultName string
+	}{
+		{"query", schema.QueryType(), "Query"},
+		{"mutation", schema.MutationType(), "Mutation"},
+		{"subscription", schema.SubscriptionType(), "Subscription"},
+	}
+
+	isDefault := true
+	lines := []string{}
+	for _, root := range roots {
+		if root.t == nil {
+			continue
+		}
+
+		isDefault = isDefault && root.t.Name() == root.defaultName
+		lines = append(lines, fmt.Sprintf("  %s: %s", root.operation, root.t.Name()))
+	}
+
+	if isDefault {
+		return ""
+	}
+
+	return "schema {\n" + strings.Join(lines, "\n") + "\n}"
+}
+
+func (builder *SchemaBuilder) printType(t graphql.Type) string {
+	var sb strings.Builder
+	printDescription(&sb, t.Description(), "")
+
+	switch t := t.(type) {
+	case *graphql.Scalar:
+		fmt.Fprintf(&sb, "scalar %s", t.Name())
+		if url := builder.specifiedByURL(t); url != "" {
+			fmt.Fprintf(&sb, " @specifiedBy(url: %s)", strconv.Quote(url))
+		}
+
+	case *graphql.Object:
+		fmt.Fprintf(&sb, "type %s", t.Name())
+		if len(t.Interfaces()) != 0 {
+			names := []string{}
+			for _, iface := range t.Interfaces() {
+				names = append(names, iface.Name())
+			}
+
+			fmt.Fprintf(&sb, " implements %s", strings.Join(names, " & "))
+		}
+
+		printFields(&sb, t.Fields())
+
+	case *graphql.Interface:
+		fmt.Fprintf(&sb, "interface %s", t.Name())
+		printFields(&sb, t.Fields())
+
+	case *graphql.Union:
+		names := []string{}
+		for _, member := range t.Types() {
+			names = append(names, member.Name())
+		}
+
+		fmt.Fprintf(&sb, "union %s = %s", t.Name(), strings.Join(names, " | "))
+
+	case *graphql.Enum:
+		// graphql-go keeps enum values in a map, so they're sorted like fields
+		values := append([]*graphql.EnumValueDefinition{}, t.Values()...)
+		sort.Slice(values, func(i, j int) bool { return values[i].Name < values[j].Name })
+
+		fmt.Fprintf(&sb, "enum %s {\n", t.Name())
+		for _, value := range values {
+			printDescription(&sb, value.Description, "  ")
+			fmt.Fprintf(&sb, "  %s%s\n", value.Name, printDeprecated(value.DeprecationReason))
+		}
+
+		sb.WriteString("}")
+
+	case *graphql.InputObject:
+		fields := t.Fields()
+		names := []string{}
+		for name := range fields {
+			names = append(names, name)
+		}
+
+		sort.Strings(names)
+		fmt.Fprintf(&sb, "input %s {\n", t.Name())
+		for _, name := range names {
+			field := fields[name]
+			printDescription(&sb, field.Description(), "  ")
+			fmt.Fprintf(&sb, "  %s\n", printInputValue(field.Name(), field.Type, field.DefaultValue))
+		}
+
+		sb.WriteString("}")
+	}
+
+	return sb.String()
+}
+
+// specifiedByURL returns the URL set with GraphQLSpecifiedBy on the Go type of
+// a scalar. graphql-go doesn't support the specifiedByURL introspection field,
+// so it's only printed in the SDL.
+func (builder *SchemaBuilder) specifiedByURL(scalar *graphql.Scalar) string {
+	for parserType, graphqlType := range builder.graphqlTypes {
+		if parserScalar, ok := parserType.(*parser.Scalar); ok && graphqlType == scalar {
+			return parserScalar.SpecifiedBy()
+		}
+	}
+
+	return ""
+}
+
+func printFields(sb *strings.Builder, fields graphql.FieldDefinitionMap) {
+	names := []string{}
+	for name := range fields {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	sb.WriteString(" {\n")
+	for _, name := range names {
+		field := fields[name]
+		printDescription(sb, field.Description, "  ")
+		fmt.Fprintf(sb, "  %s%s: %s%s\n", field.Name, printArgs(field.Args), field.Type, printDeprecated(field.DeprecationReason))
+	}
+
+	sb.WriteString("}")
+}
+
+func printArgs(args []*graphql.Argument) string {
+	if len(args) == 0 {
+		return ""
+	}
+
+	// graphql-go keeps arguments in a map, so they're sorted like fields
+	args = append([]*graphql.Argument{}, args...)
+	sort.Slice(args, func(i, j int) bool { return args[i].Name() < args[j].Name() })
+
+	hasDescription := false
+	for _, arg := range args {
+		hasDescription = hasDescription || arg.Description() != ""
+	}
+
+	if !hasDescription {
+		values := []string{}
+		for _, arg := range args {
+			values = append(values, printInputValue(arg.Name(), arg.Type, arg.DefaultValue))
+		}
+
+		return "(" + strings.Join(values, ", ") + ")"
+	}
+
+	var sb strings.Builder
+	sb.WriteString("(\n")
+	for _, arg := range args {
+		printDescription(&sb, arg.Description(), "    ")
+		fmt.Fprintf(&sb, "    %s\n", printInputValue(arg.Name(), arg.Type, arg.DefaultValue))
+	}
+
+	sb.WriteString("  )")
+	return sb.String()
+}
+
+func printInputValue(name string, t graphql.Input, defaultValue interface{}) string {
+	value := fmt.Sprintf("%s: %s", name, t)
+	if literal, ok := printDefaultValue(defaultValue, t).(string); ok {
+		value += " = " + literal
+	}
+
+	return value
+}
+
+func printDeprecated(reason string) string {
+	switch reason {
+	case "":
+		return ""
+	case graphql.DefaultDeprecationReason:
+		return " @deprecated"
+	}
+
+	return fmt.Sprintf(" @deprecated(reason: %s)", strconv.Quote(reason))
+}
+
+func printDescription(sb *strings.Builder, description string, indent string) {
+	if description == "" {
+		return
+	}
+
+	if !strings.Contains(description, "\n") {
+		fmt.Fprintf(sb, "%s%s\n", indent, strconv.Quote(description))
+		return
+	}
+
+	fmt.Fprintf(sb, "%s\"\"\"\n", indent)
+	for _, line := range strings.Split(description, "\n") {
+		line = strings.ReplaceAll(line, `"""`, `\"""`)
+		if line == "" {
+			sb.WriteString("\n")
+		} else {
+			fmt.Fprintf(sb, "%s%s\n", indent, line)
+		}
+	}
+
+	fmt.Fprintf(sb, "%s\"\"\"\n", indent)
+}
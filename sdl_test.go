@@ -0,0 +1,46 @@
+package groot_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/shreyas44/groot"
+)
+
+type SpecifiedByTime int64
+
+func (t SpecifiedByTime) MarshalJSON() ([]byte, error) {
+	return json.Marshal(int64(t))
+}
+
+func (t *SpecifiedByTime) UnmarshalJSON(b []byte) error {
+	return json.Unmarshal(b, (*int64)(t))
+}
+
+func (t *SpecifiedByTime) GraphQLName() string {
+	return "DateTime"
+}
+
+func (t *SpecifiedByTime) GraphQLSpecifiedBy() string {
+	return "https://en.wikipedia.org/wiki/Unix_time"
+}
+
+type SpecifiedByQuery struct {
+	Now SpecifiedByTime `json:"now"`
+}
+
+func TestPrintSchemaSpecifiedBy(t *testing.T) {
+	sdl, err := groot.PrintSchema(groot.SchemaConfig{
+		Query: groot.MustParseObject(SpecifiedByQuery{}),
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `scalar DateTime @specifiedBy(url: "https://en.wikipedia.org/wiki/Unix_time")`
+	if !strings.Contains(sdl, expected+"\n") {
+		t.Fatalf("expected %s in %s", expected, sdl)
+	}
+}
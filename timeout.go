@@ -0,0 +1,122 @@
+package groot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/graphql-go/graphql"
+)
+
+// TimeoutError is the error of a field whose resolver didn't finish before
+// the timeout of the field
+type TimeoutError struct {
+	Timeout time.Duration
+}
+
+func (err *TimeoutError) Error() string {
+	return fmt.Sprintf("resolver timed out after %s", err.Timeout)
+}
+
+// Extensions adds the TIMEOUT code to the error in the response
+func (err *TimeoutError) Extensions() map[string]interface{} {
+	return map[string]interface{}{"code": "TIMEOUT"}
+}
+
+// newTimeoutFieldResolver resolves a field with a deadline added to the
+// context of the resolver, and resolves to a TimeoutError once the deadline
+// is exceeded, including while waiting on a thunk returned by the resolver.
+// Async resolvers already run in goroutines and stop waiting once the context
+// is done, while other resolvers are run in the worker pool of the request, so
+// MaxConcurrency bounds them as well. The errors a resolver adds to the
+// response are only added if it returns before the deadline, so the result of
+// an abandoned resolver is dropped entirely.
+func newTimeoutFieldResolver(resolve fieldResolver, timeout time.Duration, async bool) fieldResolver {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		parent := p.Context
+		if parent == nil {
+			parent = context.Background()
+		}
+
+		ctx, cancel := context.WithTimeout(parent, timeout)
+		buffer := &fieldErrorBuffer{}
+		p.Context = context.WithValue(ctx, fieldErrorBufferKey{}, buffer)
+
+		call := func(fn func() (interface{}, error)) (interface{}, error) {
+			var (
+				value interface{}
+				err   error
+			)
+
+			if async {
+				value, err = fn()
+			} else {
+				value, err = waitWithTimeout(p.Context, fn)
+			}
+
+			if err != nil && ctx.Err() != nil && errors.Is(err, ctx.Err()) {
+				if errors.Is(err, context.DeadlineExceeded) {
+					return nil, &TimeoutError{Timeout: timeout}
+				}
+
+				return nil, err
+			}
+
+			return value, err
+		}
+
+		value, err := call(func() (interface{}, error) {
+			return resolve(p)
+		})
+
+		thunk, isThunk := value.(func() (interface{}, error))
+		if err != nil || !isThunk {
+			cancel()
+			return value, buffer.flush(p, err)
+		}
+
+		return func() (interface{}, error) {
+			defer cancel()
+			value, err := call(thunk)
+			return value, buffer.flush(p, err)
+		}, nil
+	}
+}
+
+// waitWithTimeout runs fn in a goroutine of the worker pool of the request,
+// and returns its result or the error of ctx if ctx is done first, in which
+// case fn keeps running but its result is dropped
+func waitWithTimeout(ctx context.Context, fn func() (interface{}, error)) (interface{}, error) {
+	type result struct {
+		value interface{}
+		err   error
+	}
+
+	resultCh := make(chan result, 1)
+	run := func() {
+		defer func() {
+			// graphql-go can't recover panics from other goroutines
+			if r := recover(); r != nil {
+				resultCh <- result{err: fmt.Errorf("%v", r)}
+			}
+		}()
+
+		value, err := fn()
+		resultCh <- result{value, err}
+	}
+
+	// the request wasn't executed with the extension of the schema
+	if pool := workerPoolFromContext(ctx); pool == nil {
+		go run()
+	} else if err := pool.run(ctx, run); err != nil {
+		return nil, err
+	}
+
+	select {
+	case res := <-resultCh:
+		return res.value, res.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
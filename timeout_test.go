@@ -0,0 +1,157 @@
+package groot_test
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/graphql-go/graphql"
+	"github.com/shreyas44/groot"
+)
+
+type TimeoutQuery struct {
+	Slow      *string   `json:"slow" timeout:"10ms"`
+	SlowAsync *string   `json:"slowAsync" async:"true" timeout:"10ms"`
+	Late      *[]string `json:"late" async:"true" timeout:"10ms"`
+	Partial   *[]string `json:"partial" timeout:"1s"`
+	Waiting   string    `json:"waiting" async:"true"`
+}
+
+var hello = "hello"
+
+func (query TimeoutQuery) ResolveSlow() (*string, error) {
+	time.Sleep(50 * time.Millisecond)
+	return &hello, nil
+}
+
+func (query TimeoutQuery) ResolveSlowAsync() (*string, error) {
+	time.Sleep(50 * time.Millisecond)
+	return &hello, nil
+}
+
+// ResolveLate returns a partial result after the timeout of the field, while
+// the request is still waiting on another field
+func (query TimeoutQuery) ResolveLate() ([]string, []error) {
+	time.Sleep(30 * time.Millisecond)
+	return []string{"a"}, []error{groot.NewError("LATE", "late")}
+}
+
+func (query TimeoutQuery) ResolvePartial() ([]string, []error) {
+	return []string{"a"}, []error{groot.NewError("PARTIAL", "partial")}
+}
+
+func (query TimeoutQuery) ResolveWaiting() (string, error) {
+	time.Sleep(100 * time.Millisecond)
+	return "done", nil
+}
+
+func newTimeoutSchema(t *testing.T) graphql.Schema {
+	t.Helper()
+
+	schema, err := groot.NewSchema(groot.SchemaConfig{
+		Query: groot.MustParseObject(TimeoutQuery{}),
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return schema
+}
+
+func assertErrorCodes(t *testing.T, result *graphql.Result, codes ...string) {
+	t.Helper()
+
+	if len(result.Errors) != len(codes) {
+		t.Fatalf("expected %d errors, got %v", len(codes), result.Errors)
+	}
+
+	for i, code := range codes {
+		if result.Errors[i].Extensions["code"] != code {
+			t.Fatalf("expected error with code %s, got %v", code, result.Errors[i])
+		}
+	}
+}
+
+func TestTimeout(t *testing.T) {
+	schema := newTimeoutSchema(t)
+	for _, query := range []string{"{ slow }", "{ slowAsync }"} {
+		result := graphql.Do(graphql.Params{Schema: schema, RequestString: query})
+		assertErrorCodes(t, result, "TIMEOUT")
+	}
+}
+
+func TestTimeoutDropsLateErrors(t *testing.T) {
+	schema := newTimeoutSchema(t)
+	result := graphql.Do(graphql.Params{Schema: schema, RequestString: "{ late waiting }"})
+	assertErrorCodes(t, result, "TIMEOUT")
+
+	data := result.Data.(map[string]interface{})
+	if data["late"] != nil || data["waiting"] != "done" {
+		t.Fatalf("unexpected data %v", data)
+	}
+}
+
+func TestTimeoutKeepsPartialResultErrors(t *testing.T) {
+	schema := newTimeoutSchema(t)
+	result := graphql.Do(graphql.Params{Schema: schema, RequestString: "{ partial }"})
+	assertErrorCodes(t, result, "PARTIAL")
+
+	data := result.Data.(map[string]interface{})
+	if len(data["partial"].([]interface{})) != 1 {
+		t.Fatalf("unexpected data %v", data)
+	}
+}
+
+type ConcurrencyQuery struct {
+	First  *string `json:"first"`
+	Second *string `json:"second"`
+}
+
+var running, maxRunning int32
+
+func runTracked() (*string, error) {
+	current := atomic.AddInt32(&running, 1)
+	defer atomic.AddInt32(&running, -1)
+
+	for {
+		max := atomic.LoadInt32(&maxRunning)
+		if current <= max || atomic.CompareAndSwapInt32(&maxRunning, max, current) {
+			break
+		}
+	}
+
+	time.Sleep(30 * time.Millisecond)
+	return &hello, nil
+}
+
+func (query ConcurrencyQuery) ResolveFirst() (*string, error) {
+	return runTracked()
+}
+
+func (query ConcurrencyQuery) ResolveSecond() (*string, error) {
+	return runTracked()
+}
+
+// abandoned resolvers keep their slot in the worker pool until they return,
+// so resolvers with a timeout are bounded by MaxConcurrency too
+func TestTimeoutMaxConcurrency(t *testing.T) {
+	schema, err := groot.NewSchema(groot.SchemaConfig{
+		Query:          groot.MustParseObject(ConcurrencyQuery{}),
+		DefaultTimeout: 10 * time.Millisecond,
+		MaxConcurrency: 1,
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := graphql.Do(graphql.Params{Schema: schema, RequestString: "{ first second }"})
+	assertErrorCodes(t, result, "TIMEOUT", "TIMEOUT")
+
+	// wait for the abandoned resolvers
+	time.Sleep(50 * time.Millisecond)
+	if max := atomic.LoadInt32(&maxRunning); max != 1 {
+		t.Fatalf("expected at most 1 resolver running at a time, got %d", max)
+	}
+}
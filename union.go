@@ -10,45 +10,58 @@ import (
 type UnionType = parser.UnionType
 
 func NewUnion(parserUnion *parser.Union, builder *SchemaBuilder) *graphql.Union {
+	members := []*parser.Object{}
+	for _, member := range parserUnion.Members() {
+		if builder.isTypeVisible(member) {
+			members = append(members, member)
+		}
+	}
+
 	placeholderTypes := []*graphql.Object{}
-	for range parserUnion.Members() {
+	for range members {
 		placeholderTypes = append(placeholderTypes, graphql.NewObject(graphql.ObjectConfig{
 			Name:   randSeq(10),
 			Fields: graphql.Fields{},
 		}))
 	}
 
-	// TODO: description
 	union := graphql.NewUnion(graphql.UnionConfig{
-		Name:  parserUnion.ReflectType().Name(),
-		Types: placeholderTypes,
+		Name:        parserUnion.Name(),
+		Description: parserUnion.Description(),
+		Types:       placeholderTypes,
 		ResolveType: func(p graphql.ResolveTypeParams) *graphql.Object {
 			valueType := reflect.TypeOf(p.Value)
-			return builder.reflectGrootMap[valueType].(*graphql.Object)
+			object, _ := builder.reflectGrootMap[valueType].(*graphql.Object)
+			return object
 		},
 	})
 
 	builder.addType(parserUnion, union)
 
 	types := union.Types()
-	for i, parserObject := range parserUnion.Members() {
+	for i, parserObject := range members {
 		// we're changing the underlying value in the slice
-		types[i] = NewObject(parserObject, builder)
+		types[i] = GetNullable(getOrCreateType(parserObject, builder)).(*graphql.Object)
 	}
 
 	return union
 }
 
 func resolveUnionValue(union *parser.Union, p graphql.ResolveTypeParams) reflect.Value {
+	value := reflect.Indirect(reflect.ValueOf(p.Value))
+	if !value.IsValid() {
+		return reflect.ValueOf(p.Value)
+	}
+
 	for _, member := range union.Members() {
 		name := member.ReflectType().Name()
-		field := reflect.ValueOf(p.Value).FieldByName(name)
+		field := value.FieldByName(name)
 
 		if !field.IsZero() {
 			return field
 		}
 	}
 
-	firstValue := reflect.ValueOf(p.Value).FieldByName(union.Members()[0].ReflectType().Name())
+	firstValue := value.FieldByName(union.Members()[0].ReflectType().Name())
 	return firstValue
 }
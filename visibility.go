@@ -0,0 +1,197 @@
+package groot
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/graphql-go/graphql"
+	"github.com/shreyas44/groot/parser"
+)
+
+type typeWithVisibility interface {
+	Visibility() string
+}
+
+// IncludeVisibilities returns a function for SchemaConfig.Visible which
+// includes the given visibilities in the schema
+func IncludeVisibilities(visibilities ...string) func(visibility string) bool {
+	included := map[string]bool{}
+	for _, visibility := range visibilities {
+		included[visibility] = true
+	}
+
+	return func(visibility string) bool {
+		return included[visibility]
+	}
+}
+
+// isVisible reports whether anything with the given visibility is included
+// in the schema. Everything without a visibility is always included.
+func (builder *SchemaBuilder) isVisible(visibility string) bool {
+	if visibility == "" {
+		return true
+	}
+
+	if builder.visible == nil || !builder.visible(visibility) {
+		builder.hasHidden = true
+		return false
+	}
+
+	return true
+}
+
+// isTypeVisible reports whether a type, or the element type of lists and
+// nullable types, is included in the schema
+func (builder *SchemaBuilder) isTypeVisible(t parser.Type) bool {
+	if t, ok := namedType(t).(typeWithVisibility); ok {
+		return builder.isVisible(t.Visibility())
+	}
+
+	return true
+}
+
+// namedType returns the element type of lists and nullable types
+func namedType(t parser.Type) parser.Type {
+	for {
+		withElement, ok := t.(parser.TypeWithElement)
+		if !ok {
+			return t
+		}
+
+		t = withElement.Element()
+	}
+}
+
+func (builder *SchemaBuilder) isFieldVisible(field *parser.Field) bool {
+	return builder.isVisible(field.Visibility()) && builder.isTypeVisible(field.Type())
+}
+
+func (builder *SchemaBuilder) isArgumentVisible(arg *parser.Argument) bool {
+	return builder.isVisible(arg.Visibility()) && builder.isTypeVisible(arg.Type())
+}
+
+// hideValues drops the values of hidden types from the values of fields of
+// interface and union types, since resolvers can still return them. Such
+// values resolve to null, and are left out of lists.
+func (builder *SchemaBuilder) hideValues(parserField *parser.Field, field *graphql.Field) {
+	switch namedType(parserField.Type()).(type) {
+	case *parser.Interface, *parser.Union:
+	default:
+		return
+	}
+
+	field.Resolve = newValueFieldResolver(field.Resolve, func(p graphql.ResolveParams, value interface{}) (interface{}, error) {
+		// set when the schema is built, after the resolver is created
+		if !builder.hasHidden {
+			return value, nil
+		}
+
+		return builder.visibleValue(reflect.ValueOf(value)), nil
+	})
+}
+
+// visibleValue returns a value with the values of types which aren't in the
+// schema left out
+func (builder *SchemaBuilder) visibleValue(value reflect.Value) interface{} {
+	if !value.IsValid() {
+		return nil
+	}
+
+	if value.Kind() != reflect.Slice {
+		if builder.isHiddenValue(value) {
+			return nil
+		}
+
+		return value.Interface()
+	}
+
+	if value.IsNil() {
+		return value.Interface()
+	}
+
+	visible := reflect.MakeSlice(value.Type(), 0, value.Len())
+	for i := 0; i < value.Len(); i++ {
+		if item := value.Index(i); !builder.isHiddenValue(item) {
+			visible = reflect.Append(visible, item)
+		}
+	}
+
+	return visible.Interface()
+}
+
+func (builder *SchemaBuilder) isHiddenValue(value reflect.Value) bool {
+	for value.Kind() == reflect.Interface || value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return false
+		}
+
+		value = value.Elem()
+	}
+
+	_, ok := builder.reflectGrootMap[value.Type()]
+	return !ok
+}
+
+// checkRootTypes adds an error if any of the root types is hidden
+func (builder *SchemaBuilder) checkRootTypes(roots ...*parser.Object) {
+	for _, root := range roots {
+		if root != nil && !builder.isTypeVisible(root) {
+			builder.addError(fmt.Errorf(
+				"root type %s has the visibility %s, which isn't included in the schema",
+				root.Name(),
+				root.Visibility(),
+			))
+		}
+	}
+}
+
+// getVisibleTypes creates the types listed in the schema config which are
+// included in the schema. If anything was hidden from the schema, objects
+// implementing interfaces are dropped if none of their interfaces are
+// reachable from the root types or the other types.
+func (builder *SchemaBuilder) getVisibleTypes(types []parser.Type) []parser.Type {
+	var (
+		visibleTypes = []parser.Type{}
+		pending      = []parser.Type{}
+	)
+
+	for _, t := range types {
+		if builder.isTypeVisible(t) {
+			pending = append(pending, t)
+		}
+	}
+
+	for added := true; added; {
+		added = false
+		remaining := []parser.Type{}
+
+		for _, t := range pending {
+			if object, ok := t.(*parser.Object); ok && !builder.implementsReachableInterface(object) {
+				remaining = append(remaining, t)
+				continue
+			}
+
+			getOrCreateType(t, builder)
+			visibleTypes = append(visibleTypes, t)
+			added = true
+		}
+
+		pending = remaining
+	}
+
+	return visibleTypes
+}
+
+func (builder *SchemaBuilder) implementsReachableInterface(object *parser.Object) bool {
+	if len(object.Interfaces()) == 0 || !builder.hasHidden {
+		return true
+	}
+
+	for _, parserInterface := range object.Interfaces() {
+		if _, ok := builder.getType(parserInterface); ok {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,97 @@
+package groot_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/shreyas44/groot"
+	"github.com/shreyas44/groot/parser"
+)
+
+type VisibilityNode interface {
+	ImplementsVisibilityNode() VisibilityNodeDefinition
+}
+
+type VisibilityNodeDefinition struct {
+	groot.InterfaceType
+	ID string `json:"id"`
+}
+
+func (definition VisibilityNodeDefinition) ImplementsVisibilityNode() VisibilityNodeDefinition {
+	return definition
+}
+
+type PublicNode struct {
+	VisibilityNodeDefinition
+}
+
+type SecretNode struct {
+	VisibilityNodeDefinition
+}
+
+func (SecretNode) GraphQLVisibility() string {
+	return "internal"
+}
+
+type PublicItem struct {
+	Name string `json:"name"`
+}
+
+type VisibilitySearchResult struct {
+	groot.UnionType
+	PublicItem
+	SecretNode
+}
+
+type VisibilityQuery struct {
+	Nodes  []VisibilityNode        `json:"nodes"`
+	Search *VisibilitySearchResult `json:"search"`
+}
+
+func (query VisibilityQuery) ResolveNodes() ([]VisibilityNode, error) {
+	return []VisibilityNode{
+		PublicNode{VisibilityNodeDefinition{ID: "public"}},
+		SecretNode{VisibilityNodeDefinition{ID: "secret"}},
+	}, nil
+}
+
+func (query VisibilityQuery) ResolveSearch() (*VisibilitySearchResult, error) {
+	return &VisibilitySearchResult{SecretNode: SecretNode{VisibilityNodeDefinition{ID: "secret"}}}, nil
+}
+
+func TestHiddenTypesReturnedByResolvers(t *testing.T) {
+	schema, err := groot.NewSchema(groot.SchemaConfig{
+		Query: groot.MustParseObject(VisibilityQuery{}),
+		Types: []parser.Type{groot.MustParseObject(PublicNode{}), groot.MustParseObject(SecretNode{})},
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ nodes { id } search { ... on PublicItem { name } } }`,
+	})
+
+	assertResult(t, result, `{"nodes":[{"id":"public"}],"search":null}`)
+}
+
+type HiddenQuery struct {
+	Version string `json:"version"`
+}
+
+func (HiddenQuery) GraphQLVisibility() string {
+	return "internal"
+}
+
+func TestHiddenRootType(t *testing.T) {
+	_, err := groot.NewSchema(groot.SchemaConfig{
+		Query: groot.MustParseObject(HiddenQuery{}),
+	})
+
+	if err == nil || !strings.Contains(err.Error(), "root type") {
+		t.Fatalf("expected an error for a hidden root type, got %v", err)
+	}
+}
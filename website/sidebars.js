@@ -17,6 +17,7 @@ module.exports = {
   docs: [
     "introduction",
     "getting-started",
+    "execution",
     {
       type: "category",
       label: "Type Definitions",
@@ -34,6 +35,13 @@ module.exports = {
     },
     "subscriptions",
     "context",
+    "errors",
+    "auth",
+    "complexity",
+    "persisted-queries",
+    "opentelemetry",
+    "metrics",
+    "apollo-tracing",
     "comparison",
     "composition",
     "relay",